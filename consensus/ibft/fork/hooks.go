@@ -2,7 +2,6 @@ package fork
 
 import (
 	"errors"
-	"math/big"
 
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/hook"
 	"github.com/0xPolygon/polygon-edge/contracts/staking"
@@ -119,11 +118,8 @@ func registerStakingContractDeploymentHooks(
 }
 
 // registerEnhancedStakingHooks registers hooks for enhanced staking with block rewards
+// The global supply tracker is initialized once by ForkManager.Initialize
 func registerEnhancedStakingHooks(hooks *hook.Hooks, ownerAddress string) {
-	// Initialize the secure supply tracker with initial supply from genesis
-	// This should be called once during consensus initialization
-	stakingHelper.InitializeSupplyTracker(big.NewInt(0)) // Start with 0, will be updated from genesis
-
 	hooks.PreCommitStateFunc = func(header *types.Header, txn *state.Transition) error {
 		// Use owner address from genesis configuration instead of hardcoded value
 		var ownerAddr types.Address
@@ -147,6 +143,11 @@ func registerEnhancedStakingHooks(hooks *hook.Hooks, ownerAddress string) {
 
 		return nil
 	}
+
+	hooks.PostInsertBlockFunc = func(block *types.Block) error {
+		// Record the supply-side effects of the block's fee distribution once it is final
+		return stakingHelper.CommitBlockFeeEffects(block.Number(), block.Hash())
+	}
 }
 
 // getPreDeployParams returns PredeployParams for Staking Contract from IBFTFork
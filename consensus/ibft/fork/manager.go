@@ -2,9 +2,11 @@ package fork
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/hook"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -118,7 +120,7 @@ func (m *ForkManager) Initialize() error {
 
 	m.initializeHooksRegisters()
 
-	return nil
+	return m.initializeSupplyTracker()
 }
 
 // Close calls termination process of submodules
@@ -305,6 +307,20 @@ func (m *ForkManager) initializeHooksRegisters() {
 	}
 }
 
+// initializeSupplyTracker initializes the global supply tracker from genesis
+// when PoS forks mint block rewards
+func (m *ForkManager) initializeSupplyTracker() error {
+	if _, ok := m.hooksRegisters[PoS]; !ok {
+		return nil
+	}
+
+	if err := stakingHelper.InitializeFromGenesis(); err != nil {
+		return fmt.Errorf("failed to initialize supply tracker: %w", err)
+	}
+
+	return nil
+}
+
 // initializeHooksRegister initialize HookRegister by IBFTType
 func (m *ForkManager) initializeHooksRegister(ibftType IBFTType) {
 	if _, ok := m.hooksRegisters[ibftType]; ok {
@@ -2,13 +2,16 @@ package fork
 
 import (
 	"errors"
+	"math/big"
 	"path"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/hook"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
 	testHelper "github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -253,6 +256,8 @@ func TestNewForkManager(t *testing.T) {
 	t.Run("PoS and BLS", func(t *testing.T) {
 		t.Parallel()
 
+		stakingHelper.SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{})
+
 		var (
 			epochSize uint64 = 10
 
@@ -924,3 +929,29 @@ func TestForkManager_initializeHooksRegisters(t *testing.T) {
 		fm.hooksRegisters[PoS],
 	)
 }
+
+func TestForkManager_initializeSupplyTracker(t *testing.T) {
+	defer stakingHelper.SetGenesisAllocCache(nil)
+
+	fm := &ForkManager{
+		hooksRegisters: map[IBFTType]HooksRegister{
+			PoS: NewPoSHookRegister(IBFTForks{}, 10),
+		},
+	}
+
+	stakingHelper.SetGenesisAllocCache(nil)
+
+	assert.ErrorIs(t, fm.initializeSupplyTracker(), stakingHelper.ErrGenesisNotLoaded)
+
+	stakingHelper.SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{
+		types.StringToAddress("0x1"): {Balance: big.NewInt(100)},
+	})
+
+	assert.NoError(t, fm.initializeSupplyTracker())
+	assert.Equal(t, big.NewInt(100), stakingHelper.GetCurrentSupply())
+
+	// Chains without PoS forks do not mint block rewards
+	stakingHelper.SetGenesisAllocCache(nil)
+
+	assert.NoError(t, (&ForkManager{hooksRegisters: map[IBFTType]HooksRegister{}}).initializeSupplyTracker())
+}
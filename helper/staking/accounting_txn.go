@@ -0,0 +1,61 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var ErrCreditMintMismatch = errors.New("credited balances do not match recorded mints")
+
+// AccountingTxn is an in-memory txn that tracks every balance credit,
+// used to verify that reward minting credits exactly what it records
+type AccountingTxn struct {
+	balances      map[types.Address]*big.Int
+	totalCredited *big.Int
+}
+
+// NewAccountingTxn creates a new accounting txn with empty balances
+func NewAccountingTxn() *AccountingTxn {
+	return &AccountingTxn{
+		balances:      make(map[types.Address]*big.Int),
+		totalCredited: big.NewInt(0),
+	}
+}
+
+// AddBalance credits the address and records the credit
+func (a *AccountingTxn) AddBalance(addr types.Address, amount *big.Int) {
+	balance := a.GetBalance(addr)
+	balance.Add(balance, amount)
+	a.totalCredited.Add(a.totalCredited, amount)
+}
+
+// GetBalance returns the balance of the address
+func (a *AccountingTxn) GetBalance(addr types.Address) *big.Int {
+	if _, ok := a.balances[addr]; !ok {
+		a.balances[addr] = big.NewInt(0)
+	}
+
+	return a.balances[addr]
+}
+
+// TotalCredited returns the sum of all credits made through the txn
+func (a *AccountingTxn) TotalCredited() *big.Int {
+	return new(big.Int).Set(a.totalCredited)
+}
+
+// VerifyCreditsMatchMints checks that the balances credited through the txn
+// equal the total minted according to the global supply tracker's audit log
+func VerifyCreditsMatchMints(txn *AccountingTxn) error {
+	credited := txn.TotalCredited()
+	minted := GetGlobalSupplyTracker().tracker.GetTotalMinted()
+
+	if credited.Cmp(minted) != 0 {
+		return fmt.Errorf("%w: credited %s wei, minted %s wei",
+			ErrCreditMintMismatch, credited.String(), minted.String())
+	}
+
+	return nil
+}
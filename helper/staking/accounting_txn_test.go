@@ -0,0 +1,39 @@
+package staking
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestVerifyCreditsMatchMints(t *testing.T) {
+	InitializeSupplyTracker(big.NewInt(0))
+	defer InitializeSupplyTracker(big.NewInt(0))
+
+	txn := NewAccountingTxn()
+	owner := types.StringToAddress(testOwnerAddress)
+
+	for block := uint64(1); block <= 5; block++ {
+		if _, err := GetGlobalSupplyTracker().MintRewardWithCap(txn, block, owner); err != nil {
+			t.Fatalf("Failed to mint reward: %v", err)
+		}
+	}
+
+	if err := VerifyCreditsMatchMints(txn); err != nil {
+		t.Errorf("Expected credits to match mints: %v", err)
+	}
+
+	expected := new(big.Int).Mul(big.NewInt(5), big.NewInt(BlockRewardAmount))
+	if txn.GetBalance(owner).Cmp(expected) != 0 {
+		t.Errorf("Expected owner balance %s, got %s", expected.String(), txn.GetBalance(owner).String())
+	}
+
+	// A credit that bypasses the tracker is detected
+	txn.AddBalance(owner, big.NewInt(1))
+
+	if err := VerifyCreditsMatchMints(txn); !errors.Is(err, ErrCreditMintMismatch) {
+		t.Errorf("Expected ErrCreditMintMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package staking
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Global record of block producers used by DistributeEpochRewardsByProduction
+var globalBlockProducers = newBlockProducers()
+
+// blockProducers maps block numbers to the validator that produced them
+type blockProducers struct {
+	producers map[uint64]types.Address
+	mutex     sync.Mutex
+}
+
+func newBlockProducers() *blockProducers {
+	return &blockProducers{
+		producers: make(map[uint64]types.Address),
+	}
+}
+
+// RecordBlockProducer records the validator that produced the block, for
+// DistributeEpochRewardsByProduction. Recording a block again overwrites it.
+func RecordBlockProducer(blockNumber uint64, producer types.Address) {
+	globalBlockProducers.mutex.Lock()
+	defer globalBlockProducers.mutex.Unlock()
+
+	globalBlockProducers.producers[blockNumber] = producer
+}
+
+// countAndForget counts the blocks produced per validator in [fromBlock, toBlock]
+// and drops the range from the record
+func (bp *blockProducers) countAndForget(fromBlock, toBlock uint64) map[types.Address]uint64 {
+	bp.mutex.Lock()
+	defer bp.mutex.Unlock()
+
+	counts := make(map[types.Address]uint64)
+
+	for block, producer := range bp.producers {
+		if block >= fromBlock && block <= toBlock {
+			counts[producer]++
+
+			delete(bp.producers, block)
+		}
+	}
+
+	return counts
+}
+
+// DistributeEpochRewardsByProduction mints the block rewards of the inclusive
+// [fromBlock, toBlock] range, clamped to the supply cap, and pays each validator
+// in proportion to the blocks it produced according to RecordBlockProducer. The
+// share of blocks without a recorded producer and the rounding dust go to the
+// owner. One audit entry per paid recipient is recorded at toBlock. The range's
+// producer records are dropped whatever the outcome of the mint, so they never
+// count towards a later epoch.
+func DistributeEpochRewardsByProduction(
+	txn interface{ AddBalance(types.Address, *big.Int) },
+	epoch, fromBlock, toBlock uint64,
+	ownerAddress types.Address,
+) (MintResult, error) {
+	result := MintResult{Minted: big.NewInt(0)}
+
+	if fromBlock > toBlock {
+		return result, fmt.Errorf("%w: from block %d is after to block %d", ErrInvalidBlockRange, fromBlock, toBlock)
+	}
+
+	if !isMintingEnabled() {
+		return result, newSupplyError(ErrMintingPaused, toBlock, nil)
+	}
+
+	sst := GetGlobalSupplyTracker()
+	blocks := toBlock - fromBlock + 1
+	counts := globalBlockProducers.countAndForget(fromBlock, toBlock)
+
+	producers := 0
+
+	result, err := sst.mintRewards(toBlock, func() (MintResult, error) {
+		return sst.mintRewardsLocked(txn, rewardMint{
+			firstBlock: fromBlock,
+			block:      toBlock,
+			blocks:     blocks,
+			reward:     new(big.Int).Mul(new(big.Int).SetUint64(blocks), big.NewInt(BlockRewardAmount)),
+			reason:     fmt.Sprintf("%s:%d", ReasonEpochReward, epoch),
+			split: func(minted *big.Int) []rewardPayout {
+				payouts := productionPayouts(minted, counts, blocks, ownerAddress)
+				producers = len(payouts) - 1
+
+				return payouts
+			},
+		})
+	})
+	if err != nil || result.Minted.Sign() == 0 {
+		return result, err
+	}
+
+	getSupplyLogger().Debug("epoch reward split among producers", "epoch", epoch,
+		"reward", FormatAZE(result.Minted), "fromBlock", fromBlock, "toBlock", toBlock, "producers", producers)
+
+	return result, nil
+}
+
+// productionPayouts splits amount among the producers in proportion to the number
+// of the blocks they produced, in address order so the credits are deterministic.
+// The share of blocks without a recorded producer and the rounding dust go to the
+// owner, whose payout comes last.
+func productionPayouts(
+	amount *big.Int,
+	counts map[types.Address]uint64,
+	blocks uint64,
+	ownerAddress types.Address,
+) []rewardPayout {
+	producers := make([]types.Address, 0, len(counts))
+	for producer := range counts {
+		producers = append(producers, producer)
+	}
+
+	sort.Slice(producers, func(i, j int) bool {
+		return producers[i].String() < producers[j].String()
+	})
+
+	payouts := make([]rewardPayout, 0, len(producers)+1)
+	distributed := big.NewInt(0)
+	totalBlocks := new(big.Int).SetUint64(blocks)
+
+	for _, producer := range producers {
+		share := new(big.Int).Mul(amount, new(big.Int).SetUint64(counts[producer]))
+		share.Div(share, totalBlocks)
+
+		payouts = append(payouts, rewardPayout{recipient: producer, amount: share})
+		distributed.Add(distributed, share)
+	}
+
+	return append(payouts, rewardPayout{recipient: ownerAddress, amount: new(big.Int).Sub(amount, distributed)})
+}
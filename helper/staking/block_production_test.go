@@ -0,0 +1,82 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestDistributeEpochRewardsByProduction(t *testing.T) {
+	InitializeSupplyTracker(big.NewInt(0))
+	defer InitializeSupplyTracker(big.NewInt(0))
+
+	owner := types.StringToAddress(testOwnerAddress)
+	validatorA := types.StringToAddress("0x1")
+	validatorB := types.StringToAddress("0x2")
+	txn := newMockTxn()
+
+	// Validator B joins mid-epoch and block 4 has no recorded producer
+	RecordBlockProducer(1, validatorA)
+	RecordBlockProducer(2, validatorA)
+	RecordBlockProducer(3, validatorB)
+	RecordBlockProducer(9, validatorB)
+
+	result, err := DistributeEpochRewardsByProduction(txn, 1, 1, 4, owner)
+	if err != nil {
+		t.Fatalf("Failed to distribute epoch rewards: %v", err)
+	}
+
+	reward := big.NewInt(BlockRewardAmount)
+	if result.Minted.Cmp(new(big.Int).Mul(big.NewInt(4), reward)) != 0 {
+		t.Fatalf("Expected 4 block rewards minted, got %s", result.Minted.String())
+	}
+
+	expected := map[types.Address]*big.Int{
+		validatorA: new(big.Int).Mul(big.NewInt(2), reward),
+		validatorB: reward,
+		owner:      reward,
+	}
+
+	for addr, amount := range expected {
+		if txn.GetBalance(addr).Cmp(amount) != 0 {
+			t.Errorf("Expected %s to receive %s, got %s", addr, amount.String(), txn.GetBalance(addr).String())
+		}
+	}
+
+	auditLog := GetSupplyAuditLog()
+	if len(auditLog) != len(expected) {
+		t.Fatalf("Expected an audit entry per recipient, got %+v", auditLog)
+	}
+
+	for _, entry := range auditLog {
+		if entry.BlockNumber != 4 || entry.Recipient == nil || entry.Amount.Cmp(expected[*entry.Recipient]) != 0 {
+			t.Errorf("Unexpected audit entry: %+v", entry)
+		}
+	}
+
+	// The distributed range is forgotten, later blocks are kept
+	if counts := globalBlockProducers.countAndForget(0, 100); len(counts) != 1 || counts[validatorB] != 1 {
+		t.Errorf("Expected only block 9 to remain recorded, got %v", counts)
+	}
+}
+
+func TestDistributeEpochRewardsByProductionAtCap(t *testing.T) {
+	InitializeSupplyTracker(getMaxSupply())
+	defer InitializeSupplyTracker(big.NewInt(0))
+
+	validatorA := types.StringToAddress("0x1")
+
+	RecordBlockProducer(10, validatorA)
+	RecordBlockProducer(11, validatorA)
+
+	result, err := DistributeEpochRewardsByProduction(newMockTxn(), 2, 10, 11, types.StringToAddress(testOwnerAddress))
+	if err != nil || result.Minted.Sign() != 0 || !result.CapReached {
+		t.Fatalf("Expected nothing minted at the cap, got %+v, %v", result, err)
+	}
+
+	// The epoch's producers are forgotten even though nothing was minted
+	if counts := globalBlockProducers.countAndForget(0, 100); len(counts) != 0 {
+		t.Errorf("Expected no producers to remain recorded, got %v", counts)
+	}
+}
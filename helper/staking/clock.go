@@ -0,0 +1,16 @@
+package staking
+
+import "time"
+
+// Clock provides the current time for audit entry timestamps, so tests can
+// substitute a fixed clock
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the wall clock
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,125 @@
+package staking
+
+import (
+	"math/big"
+	"sort"
+)
+
+// EmissionEra is one phase of an emission schedule. Blocks from StartBlock up to
+// the start of the next era are rewarded with Reward, halved every HalvingInterval
+// blocks counted from StartBlock. A zero HalvingInterval keeps the reward flat and
+// a nil or zero Reward emits nothing for the era.
+type EmissionEra struct {
+	StartBlock      uint64
+	Reward          *big.Int
+	HalvingInterval uint64
+}
+
+// defaultEmissionSchedule is the fixed reward of BlockRewardAmount for every block
+var defaultEmissionSchedule = []EmissionEra{
+	{StartBlock: 1, Reward: big.NewInt(BlockRewardAmount)},
+}
+
+// SupplyFromSchedule returns the genesis total, net of the genesis burns, plus the
+// rewards emitted for blocks 1 through blockNumber under the schedule. Eras are
+// applied in StartBlock order and blocks before the first era emit nothing. The
+// result is not clamped to the max supply.
+func SupplyFromSchedule(blockNumber uint64, schedule []EmissionEra) *big.Int {
+	return new(big.Int).Add(getCirculatingGenesisTotal(), emittedFromSchedule(blockNumber, schedule))
+}
+
+// CurrentEmissionRate returns the reward minted at the given block under the
+// active emission schedule, clamped to what remains below the cap like
+// MintBlockReward, so it is zero once the cap is reached. Unlike the projected
+// supply this is the marginal per-block rate.
+func CurrentEmissionRate(blockNumber uint64) *big.Int {
+	return computeMintableReward(
+		SupplyFromSchedule(blockNumber, defaultEmissionSchedule),
+		rewardAtBlock(blockNumber, defaultEmissionSchedule),
+		getMaxSupply(),
+	)
+}
+
+// rewardAtBlock returns the scheduled reward for the given block, zero for block 0
+func rewardAtBlock(blockNumber uint64, schedule []EmissionEra) *big.Int {
+	if blockNumber == 0 {
+		return big.NewInt(0)
+	}
+
+	emitted := emittedFromSchedule(blockNumber, schedule)
+
+	return emitted.Sub(emitted, emittedFromSchedule(blockNumber-1, schedule))
+}
+
+// emittedFromSchedule sums the rewards for blocks 1 through blockNumber
+func emittedFromSchedule(blockNumber uint64, schedule []EmissionEra) *big.Int {
+	eras := make([]EmissionEra, len(schedule))
+	copy(eras, schedule)
+	sort.SliceStable(eras, func(i, j int) bool {
+		return eras[i].StartBlock < eras[j].StartBlock
+	})
+
+	emitted := big.NewInt(0)
+
+	for i, era := range eras {
+		first := era.StartBlock
+		if first == 0 {
+			first = 1
+		}
+
+		last := blockNumber
+		if i+1 < len(eras) && eras[i+1].StartBlock <= last {
+			if eras[i+1].StartBlock == 0 {
+				continue
+			}
+
+			last = eras[i+1].StartBlock - 1
+		}
+
+		if first > last || era.Reward == nil || era.Reward.Sign() <= 0 {
+			continue
+		}
+
+		emitted.Add(emitted, emittedInEra(era, first, last))
+	}
+
+	return emitted
+}
+
+// emittedInEra sums the era's rewards for blocks first through last, walking
+// one halving period at a time rather than block by block
+func emittedInEra(era EmissionEra, first, last uint64) *big.Int {
+	if era.HalvingInterval == 0 {
+		blocks := new(big.Int).SetUint64(last - first + 1)
+
+		return blocks.Mul(blocks, era.Reward)
+	}
+
+	emitted := big.NewInt(0)
+	reward := new(big.Int).Set(era.Reward)
+
+	// Index of the halving period containing the first block
+	period := (first - era.StartBlock) / era.HalvingInterval
+	reward.Rsh(reward, uint(min(period, uint64(reward.BitLen()))))
+
+	for block := first; block <= last && reward.Sign() > 0; {
+		periodEnd := era.StartBlock + (period+1)*era.HalvingInterval - 1
+		if periodEnd < block || periodEnd > last {
+			// Clamp to the range, also when the period end overflows
+			periodEnd = last
+		}
+
+		blocks := new(big.Int).SetUint64(periodEnd - block + 1)
+		emitted.Add(emitted, blocks.Mul(blocks, reward))
+
+		if periodEnd == last {
+			break
+		}
+
+		block = periodEnd + 1
+		period++
+		reward.Rsh(reward, 1)
+	}
+
+	return emitted
+}
@@ -0,0 +1,112 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestSupplyFromSchedule(t *testing.T) {
+	defer SetGenesisAllocCache(nil)
+
+	SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{
+		types.StringToAddress("0x1"): {Balance: big.NewInt(1000)},
+	})
+
+	// Flat 100 per block for blocks 1-10, then 64 halving every 4 blocks
+	schedule := []EmissionEra{
+		{StartBlock: 11, Reward: big.NewInt(64), HalvingInterval: 4},
+		{StartBlock: 1, Reward: big.NewInt(100)},
+	}
+
+	cases := []struct {
+		block    uint64
+		expected int64
+	}{
+		{0, 1000},
+		{5, 1000 + 500},
+		{10, 1000 + 1000},
+		// Mid-era: 64 for blocks 11-14, then 32 for blocks 15-16
+		{16, 1000 + 1000 + 4*64 + 2*32},
+		// Halving until the reward reaches zero: 4*(64+32+16+8+4+2+1)
+		{1000, 1000 + 1000 + 4*127},
+	}
+
+	for _, c := range cases {
+		if got := SupplyFromSchedule(c.block, schedule); got.Cmp(big.NewInt(c.expected)) != 0 {
+			t.Errorf("Block %d: expected %d, got %s", c.block, c.expected, got.String())
+		}
+	}
+
+	// A zero-reward era pauses emission until the next era starts
+	paused := []EmissionEra{
+		{StartBlock: 1, Reward: big.NewInt(10)},
+		{StartBlock: 4, Reward: big.NewInt(0)},
+		{StartBlock: 8, Reward: big.NewInt(1)},
+	}
+
+	if got := SupplyFromSchedule(9, paused); got.Cmp(big.NewInt(1000+30+2)) != 0 {
+		t.Errorf("Expected zero-reward era to emit nothing, got %s", got.String())
+	}
+
+	// The default schedule matches the fixed block reward formula
+	if got := SupplyFromSchedule(7, defaultEmissionSchedule); got.Cmp(getCurrentSupplyFromBlockNumber(7)) != 0 {
+		t.Errorf("Expected default schedule to match the block formula, got %s", got.String())
+	}
+}
+
+func TestCurrentEmissionRate(t *testing.T) {
+	defaultMax := getMaxSupply()
+	defer func() {
+		if err := SetMaxSupply(defaultMax); err != nil {
+			t.Fatalf("Failed to restore max supply: %v", err)
+		}
+
+		SetGenesisAllocCache(nil)
+	}()
+
+	SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{
+		types.StringToAddress("0x1"): {Balance: big.NewInt(1000)},
+	})
+
+	schedule := []EmissionEra{
+		{StartBlock: 1, Reward: big.NewInt(100)},
+		{StartBlock: 11, Reward: big.NewInt(64), HalvingInterval: 4},
+	}
+
+	for block, expected := range map[uint64]int64{0: 0, 1: 100, 10: 100, 11: 64, 14: 64, 15: 32, 19: 16} {
+		if got := rewardAtBlock(block, schedule); got.Cmp(big.NewInt(expected)) != 0 {
+			t.Errorf("Block %d: expected reward %d, got %s", block, expected, got.String())
+		}
+	}
+
+	reward := big.NewInt(BlockRewardAmount)
+
+	if got := CurrentEmissionRate(1); got.Cmp(reward) != 0 {
+		t.Errorf("Expected the full block reward, got %s", got.String())
+	}
+
+	// Cap right after block 3's reward plus half a reward
+	capped := new(big.Int).Add(big.NewInt(1000), new(big.Int).Mul(reward, big.NewInt(3)))
+	capped.Add(capped, new(big.Int).Div(reward, big.NewInt(2)))
+
+	if err := SetMaxSupply(capped); err != nil {
+		t.Fatalf("Failed to set max supply: %v", err)
+	}
+
+	if got := CurrentEmissionRate(3); got.Cmp(new(big.Int).Div(reward, big.NewInt(2))) != 0 {
+		t.Errorf("Expected the partial reward below the cap, got %s", got.String())
+	}
+
+	if got := CurrentEmissionRate(4); got.Sign() != 0 {
+		t.Errorf("Expected zero emission past the cap, got %s", got.String())
+	}
+
+	for block := uint64(1); block <= 5; block++ {
+		if CanMintAtBlock(block) != (CurrentEmissionRate(block).Sign() > 0) {
+			t.Errorf("Block %d: CanMintAtBlock disagrees with CurrentEmissionRate", block)
+		}
+	}
+}
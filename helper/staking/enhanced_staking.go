@@ -1,30 +1,87 @@
 package staking
 
 import (
+	"bytes"
 	"fmt"
+	"math"
 	"math/big"
+	"sort"
+	"sync"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
 const (
 	// Block reward amount (1 AZE)
 	BlockRewardAmount = 1000000000000000000 // 1 * 10^18 wei
+
+	// Basis points representing 100%
+	maxBasisPoints = 10000
+
+	// Burn reason recorded when a fee share is routed to the zero address
+	ReasonZeroAddressFee = "zero_address_fee"
+
+	// Burn reason recorded when a validator's stake is slashed
+	ReasonSlash = "slash"
+
+	// Burn reason recorded by BurnFromAccount
+	ReasonAccountBurn = "account_burn"
+
+	// Burn reason recorded when a fee share belongs to a paused recipient
+	ReasonPausedPayout = "paused_payout"
+
+	// Mint reason prefix recorded for rewards minted over a block range
+	ReasonBlockRewardRange = "block_reward_range"
+
+	// Mint reason prefix recorded for rewards distributed at the end of an epoch
+	ReasonEpochReward = "epoch_reward"
+
+	// Caller recorded for the audit entries written by ReconcileGenesisChange
+	GenesisAdjustmentCaller = "genesis_adjustment"
+
+	// Caller recorded for the audit entries written for the configured genesis burns
+	GenesisBurnCaller = "genesis_burn"
+
+	// Sentinel returned by BlockWhereCapReached when the cap is never reached
+	CapNeverReached = math.MaxUint64
+
+	// Gas available to the contract call made by MintAndNotify
+	mintNotifyGasLimit = 1000000
+
+	// Gas available to the deposit call made by DistributeTxFeesToTreasury
+	treasuryDepositGasLimit = 1000000
 )
 
 var (
 	// Global supply tracker instance
 	globalSupplyTracker *SystemSupplyTracker
+	// Guards globalSupplyTracker
+	globalTrackerLock sync.RWMutex
+	// BurnAddress is the well-known address burned tokens are sent to
+	BurnAddress = types.StringToAddress("0x000000000000000000000000000000000000dEaD")
+	// StakingContractAddress is where CheckStakingContractDeployed looks for the staking contract
+	StakingContractAddress = types.StringToAddress("0x0000000000000000000000000000000000001001")
+	// Guards StakingContractAddress
+	stakingContractLock sync.RWMutex
+	// Whether BurnFromAccount sends burned tokens to BurnAddress
+	burnToDeadAddress bool
+	burnToDeadLock    sync.RWMutex
 	// Cache for genesis premine to avoid recalculating
 	genesisTotal *big.Int
 	// Global cache for genesis Alloc
 	GenesisAllocCache map[types.Address]*chain.GenesisAccount
+	// Portions of the genesis allocation burned at block 0
+	genesisBurns []GenesisBurn
+	// Guards GenesisAllocCache, genesisTotal and genesisBurns
+	genesisLock sync.RWMutex
 )
 
 // StateTransition interface to abstract the state transition operations
 type StateTransition interface {
 	AddBalance(addr types.Address, amount *big.Int)
+	SubBalance(addr types.Address, amount *big.Int) error
 	GetBalance(addr types.Address) *big.Int
 	Call2(caller types.Address, to types.Address, input []byte, value *big.Int, gas uint64) ExecutionResult
 	AccountExists(addr types.Address) bool
@@ -38,45 +95,198 @@ type ExecutionResult interface {
 
 // InitializeSupplyTracker initializes the global supply tracker
 func InitializeSupplyTracker(initialSupply *big.Int) {
-	globalSupplyTracker = NewSystemSupplyTracker(initialSupply)
+	globalTrackerLock.Lock()
+	defer globalTrackerLock.Unlock()
+
+	installGlobalSupplyTrackerLocked(NewSystemSupplyTracker(initialSupply))
+}
+
+// installGlobalSupplyTrackerLocked makes sst the global tracker, moving the
+// publishing of the supply gauges over to it (caller must hold globalTrackerLock)
+func installGlobalSupplyTrackerLocked(sst *SystemSupplyTracker) {
+	if globalSupplyTracker != nil {
+		globalSupplyTracker.tracker.publishMetrics.Store(false)
+	}
+
+	globalSupplyTracker = sst
+
+	st := sst.tracker
+	st.publishMetrics.Store(true)
+
+	st.mutex.Lock()
+	st.updateMetrics()
+	st.mutex.Unlock()
+}
+
+// InitializeFromGenesis initializes the global supply tracker with the genesis
+// allocation total as initial supply, then records the configured genesis burns
+// as block 0 burns. The genesis allocation cache must be set.
+func InitializeFromGenesis() error {
+	if GetGenesisAllocCache() == nil {
+		return ErrGenesisNotLoaded
+	}
+
+	genesisTotal := getGenesisTotal()
+	burns := GetGenesisBurns()
+
+	if burned := getGenesisBurnTotal(); burned.Cmp(genesisTotal) > 0 {
+		return fmt.Errorf("%w: genesis burns of %s wei exceed the genesis total of %s wei",
+			ErrInsufficientSupply, burned.String(), genesisTotal.String())
+	}
+
+	InitializeSupplyTracker(genesisTotal)
+
+	tracker := GetGlobalSupplyTracker().tracker
+
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	for _, burn := range burns {
+		if err := tracker.burnLocked(burn.Amount, 0, GenesisBurnCaller, burn.Reason, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-// UpdateSupplyTrackerWithTotalSupply updates the supply tracker with the actual total supply
-func UpdateSupplyTrackerWithTotalSupply(totalSupply *big.Int) {
+// UpdateSupplyTrackerWithTotalSupply updates the supply tracker with the actual total supply.
+// An existing tracker keeps its audit log, so the new initial supply is validated
+// against it; see SupplyTracker.SetInitialSupply.
+func UpdateSupplyTrackerWithTotalSupply(totalSupply *big.Int) error {
+	globalTrackerLock.Lock()
+	defer globalTrackerLock.Unlock()
+
 	if globalSupplyTracker == nil {
-		globalSupplyTracker = NewSystemSupplyTracker(totalSupply)
-		return
+		installGlobalSupplyTrackerLocked(NewSystemSupplyTracker(totalSupply))
+		return nil
 	}
 
 	// Update the initial supply in the existing tracker
-	globalSupplyTracker.tracker.initialSupply = totalSupply
+	return globalSupplyTracker.SetInitialSupply(totalSupply)
 }
 
-// GetGlobalSupplyTracker returns the global supply tracker instance
+// GetGlobalSupplyTracker returns the global supply tracker instance, lazily
+// creating a zero-supply tracker if none was initialized. Exactly one tracker is
+// created even under concurrent calls, and an explicit initialization is never
+// replaced by the lazy one.
 func GetGlobalSupplyTracker() *SystemSupplyTracker {
+	globalTrackerLock.RLock()
+	tracker := globalSupplyTracker
+	globalTrackerLock.RUnlock()
+
+	if tracker != nil {
+		return tracker
+	}
+
+	globalTrackerLock.Lock()
+	defer globalTrackerLock.Unlock()
+
 	if globalSupplyTracker == nil {
 		// Initialize with zero if not already initialized
-		globalSupplyTracker = NewSystemSupplyTracker(big.NewInt(0))
+		installGlobalSupplyTrackerLocked(NewSystemSupplyTracker(big.NewInt(0)))
 	}
+
 	return globalSupplyTracker
 }
 
 // SetGenesisAllocCache sets the genesis allocation cache
 func SetGenesisAllocCache(alloc map[types.Address]*chain.GenesisAccount) {
+	genesisLock.Lock()
+	defer genesisLock.Unlock()
+
 	GenesisAllocCache = alloc
 	// Calculate and cache genesis total
-	genesisTotal = calculateGenesisTotal()
+	genesisTotal = calculateGenesisTotalFrom(alloc)
+}
+
+// GenesisBurn is a portion of the genesis allocation burned at block 0, for
+// example to model locked or vested tokens
+type GenesisBurn struct {
+	Amount *big.Int
+	Reason string
+}
+
+// SetGenesisBurns sets the burns applied by InitializeFromGenesis. Every burn is
+// recorded as a separate block 0 audit entry by GenesisBurnCaller carrying its
+// reason, and is netted from the genesis total in the deterministic supply
+// formula. Amounts must be positive.
+func SetGenesisBurns(burns []GenesisBurn) error {
+	for i, burn := range burns {
+		if burn.Amount == nil || burn.Amount.Sign() <= 0 {
+			return fmt.Errorf("%w: genesis burn %d must be positive", ErrInvalidAmount, i)
+		}
+	}
+
+	genesisLock.Lock()
+	defer genesisLock.Unlock()
+
+	genesisBurns = copyGenesisBurns(burns)
+
+	return nil
+}
+
+// GetGenesisBurns returns a copy of the configured genesis burns
+func GetGenesisBurns() []GenesisBurn {
+	genesisLock.RLock()
+	defer genesisLock.RUnlock()
+
+	return copyGenesisBurns(genesisBurns)
+}
+
+func copyGenesisBurns(burns []GenesisBurn) []GenesisBurn {
+	if burns == nil {
+		return nil
+	}
+
+	res := make([]GenesisBurn, len(burns))
+	for i, burn := range burns {
+		res[i] = GenesisBurn{Amount: new(big.Int).Set(burn.Amount), Reason: burn.Reason}
+	}
+
+	return res
+}
+
+// getGenesisBurnTotal returns the sum of the configured genesis burns
+func getGenesisBurnTotal() *big.Int {
+	genesisLock.RLock()
+	defer genesisLock.RUnlock()
+
+	total := big.NewInt(0)
+	for _, burn := range genesisBurns {
+		total.Add(total, burn.Amount)
+	}
+
+	return total
+}
+
+// getCirculatingGenesisTotal returns the genesis total net of the genesis burns
+func getCirculatingGenesisTotal() *big.Int {
+	return new(big.Int).Sub(getGenesisTotal(), getGenesisBurnTotal())
+}
+
+// GetGenesisAllocCache returns the genesis allocation cache
+func GetGenesisAllocCache() map[types.Address]*chain.GenesisAccount {
+	genesisLock.RLock()
+	defer genesisLock.RUnlock()
+
+	return GenesisAllocCache
 }
 
 // calculateGenesisTotal calculates the total premine from genesis allocation
 func calculateGenesisTotal() *big.Int {
-	if GenesisAllocCache == nil {
-		fmt.Println("[GENESIS TOTAL] GenesisAllocCache is nil, returning 0")
+	return calculateGenesisTotalFrom(GetGenesisAllocCache())
+}
+
+// calculateGenesisTotalFrom calculates the total premine from the given genesis allocation
+func calculateGenesisTotalFrom(alloc map[types.Address]*chain.GenesisAccount) *big.Int {
+	if alloc == nil {
+		getSupplyLogger().Warn("genesis allocation not loaded, genesis total is 0")
 		return big.NewInt(0)
 	}
 
 	total := big.NewInt(0)
-	for addr, acc := range GenesisAllocCache {
+	for addr, acc := range alloc {
 		// Skip zero address as it's used for system operations
 		if addr == types.ZeroAddress {
 			continue
@@ -86,43 +296,83 @@ func calculateGenesisTotal() *big.Int {
 		}
 	}
 
-	// Convert to AZE for logging
-	totalAZE := new(big.Float).Quo(new(big.Float).SetInt(total), big.NewFloat(1e18))
-	fmt.Printf("[GENESIS TOTAL] Calculated genesis total: %s AZE (%s wei)\n",
-		totalAZE.Text('f', 0), total.String())
+	// Log the total in AZE
+	getSupplyLogger().Debug("calculated genesis total", "total", FormatAZE(total), "wei", total.String())
 
 	return total
 }
 
+// AddressBalance is a single genesis allocation entry
+type AddressBalance struct {
+	Address types.Address
+	Balance *big.Int
+}
+
+// GenesisAllocBreakdown returns each address counted in the genesis total with its
+// balance, sorted by descending balance, along with the grand total. The zero
+// address is excluded, as in calculateGenesisTotal.
+func GenesisAllocBreakdown() ([]AddressBalance, *big.Int) {
+	alloc := GetGenesisAllocCache()
+
+	breakdown := make([]AddressBalance, 0, len(alloc))
+	total := big.NewInt(0)
+
+	for addr, acc := range alloc {
+		if addr == types.ZeroAddress || acc.Balance == nil {
+			continue
+		}
+
+		breakdown = append(breakdown, AddressBalance{
+			Address: addr,
+			Balance: new(big.Int).Set(acc.Balance),
+		})
+		total.Add(total, acc.Balance)
+	}
+
+	sort.Slice(breakdown, func(i, j int) bool {
+		if cmp := breakdown[i].Balance.Cmp(breakdown[j].Balance); cmp != 0 {
+			return cmp > 0
+		}
+
+		return bytes.Compare(breakdown[i].Address.Bytes(), breakdown[j].Address.Bytes()) < 0
+	})
+
+	return breakdown, total
+}
+
 // getGenesisTotal returns the cached genesis total
 func getGenesisTotal() *big.Int {
-	if genesisTotal == nil {
-		genesisTotal = calculateGenesisTotal()
+	genesisLock.RLock()
+	cached := genesisTotal
+	genesisLock.RUnlock()
+
+	if cached == nil {
+		genesisLock.Lock()
+		if genesisTotal == nil {
+			genesisTotal = calculateGenesisTotalFrom(GenesisAllocCache)
+		}
+
+		cached = genesisTotal
+		genesisLock.Unlock()
 	}
-	return new(big.Int).Set(genesisTotal) // Return a copy
+
+	return new(big.Int).Set(cached) // Return a copy
 }
 
 // getCurrentSupplyFromBlockNumber calculates supply using deterministic formula:
-// Current Supply = Genesis Total + (Block Number * 1 AZE)
+// Current Supply = Genesis Total - Genesis Burns + (Block Number * 1 AZE)
 func getCurrentSupplyFromBlockNumber(blockNumber uint64) *big.Int {
-	genesisTotal := getGenesisTotal()
+	genesisTotal := getCirculatingGenesisTotal()
 
-	// Calculate block rewards minted so far
-	blockRewards := new(big.Int).Mul(
-		big.NewInt(int64(blockNumber)),
-		big.NewInt(BlockRewardAmount), // 1 AZE per block
-	)
+	// Calculate block rewards minted so far, 1 AZE per block
+	blockRewards := emittedFromSchedule(blockNumber, defaultEmissionSchedule)
 
 	// Total supply = Genesis total + Block rewards
 	currentSupply := new(big.Int).Add(genesisTotal, blockRewards)
 
-	// Log for debugging
-	genesisAZE := new(big.Float).Quo(new(big.Float).SetInt(genesisTotal), big.NewFloat(1e18))
-	blockRewardsAZE := new(big.Float).Quo(new(big.Float).SetInt(blockRewards), big.NewFloat(1e18))
-	currentSupplyAZE := new(big.Float).Quo(new(big.Float).SetInt(currentSupply), big.NewFloat(1e18))
-
-	fmt.Printf("[SUPPLY CALC] Block %d: Genesis=%s AZE + BlockRewards=%s AZE = Total=%s AZE\n",
-		blockNumber, genesisAZE.Text('f', 0), blockRewardsAZE.Text('f', 0), currentSupplyAZE.Text('f', 0))
+	getSupplyLogger().Debug("deterministic supply",
+		"block", blockNumber, "genesis", FormatAZE(genesisTotal),
+		"blockRewards", FormatAZE(blockRewards), "total", FormatAZE(currentSupply))
 
 	return currentSupply
 }
@@ -140,79 +390,514 @@ func MintBlockReward(
 	blockNumber uint64,
 	ownerAddress types.Address,
 ) error {
+	if !isMintingEnabled() {
+		return newSupplyError(ErrMintingPaused, blockNumber, big.NewInt(BlockRewardAmount))
+	}
+
 	// Use deterministic supply calculation: Genesis + (Block Number * 1 AZE)
 	currentSupply := getCurrentSupplyFromBlockNumber(blockNumber)
 
 	blockReward := big.NewInt(BlockRewardAmount) // 1 AZE
 
-	// Maximum supply: 1 billion AZE
-	maxSupply := new(big.Int)
-	maxSupply.SetString("1000000000000000000000000000", 10) // 1 billion AZE in wei
+	maxSupply := getMaxSupply()
 
 	// Log current state
-	currentSupplyAZE := new(big.Float).Quo(new(big.Float).SetInt(currentSupply), big.NewFloat(1e18))
-	maxSupplyAZE := new(big.Float).Quo(new(big.Float).SetInt(maxSupply), big.NewFloat(1e18))
-	fmt.Printf("[SUPPLY CAP] Block %d: New Supply would be = %s AZE, Max Supply = %s AZE\n",
-		blockNumber, currentSupplyAZE.Text('f', 0), maxSupplyAZE.Text('f', 0))
+	getSupplyLogger().Debug("checking block reward against the supply cap", "block", blockNumber,
+		"supply", FormatAZE(currentSupply), "maxSupply", FormatAZE(maxSupply))
 
 	// Check if minting 1 more AZE would exceed the cap
-	newSupply := new(big.Int).Add(currentSupply, blockReward)
-	if newSupply.Cmp(maxSupply) > 0 {
-		fmt.Printf("[SUPPLY CAP] Block %d: Cannot mint full reward - would exceed cap\n", blockNumber)
+	mintable := computeMintableReward(currentSupply, blockReward, maxSupply)
+	if mintable.Cmp(blockReward) < 0 {
+		getSupplyLogger().Debug("full block reward would exceed the supply cap", "block", blockNumber)
+
+		if mintable.Sign() == 0 {
+			getSupplyLogger().Debug("supply cap reached, no reward minted", "block", blockNumber)
+			notifyCapReached(blockNumber)
 
-		// Calculate remaining tokens that can be minted
-		remaining := new(big.Int).Sub(maxSupply, currentSupply)
-		if remaining.Sign() <= 0 {
-			fmt.Printf("[SUPPLY CAP] Block %d: Supply cap reached! No reward minted.\n", blockNumber)
 			return nil // Cap already reached, no more minting
 		}
 
 		// Mint only the remaining amount to reach cap exactly
-		remainingAZE := new(big.Float).Quo(new(big.Float).SetInt(remaining), big.NewFloat(1e18))
-		fmt.Printf("[SUPPLY CAP] Block %d: Minting partial reward: %s AZE (remaining to cap)\n",
-			blockNumber, remainingAZE.Text('f', 0))
+		getSupplyLogger().Debug("minting partial block reward", "block", blockNumber, "reward", FormatAZE(mintable))
+
+		txn.AddBalance(ownerAddress, mintable)
+		notifyCapReached(blockNumber)
 
-		txn.AddBalance(ownerAddress, remaining)
 		return nil
 	}
 
 	// We can mint the full 1 AZE reward
 	txn.AddBalance(ownerAddress, blockReward)
 
-	finalSupplyAZE := new(big.Float).Quo(new(big.Float).SetInt(newSupply), big.NewFloat(1e18))
-	fmt.Printf("[SUPPLY CAP] Block %d: Minted 1 AZE reward. New supply: %s AZE\n",
-		blockNumber, finalSupplyAZE.Text('f', 0))
+	newSupply := new(big.Int).Add(currentSupply, blockReward)
+
+	getSupplyLogger().Debug("minted block reward", "block", blockNumber, "supply", FormatAZE(newSupply))
+
+	if newSupply.Cmp(maxSupply) >= 0 {
+		notifyCapReached(blockNumber)
+	}
 
 	return nil
 }
 
-// DistributeTxFeesToValidator distributes transaction fees: 50% to owner, 50% to block producer
+// MintAndNotify mints the cap-clamped block reward to a contract and then calls it
+// with the given selector from the system caller, so the contract can account for
+// the deposit. The reward goes through the same checks as every other capped
+// reward mint and is reserved while the contract is called outside the tracker
+// lock. When the call fails the credit is reverted and the failed ExecutionResult
+// is returned alongside ErrMintNotifyFailed; if the credit cannot be reverted the
+// reward stays minted and is recorded like a successful mint. Once the cap is
+// reached nothing is minted, the contract is not called and a nil result is returned.
+func MintAndNotify(
+	transition StateTransition,
+	blockNumber uint64,
+	contract types.Address,
+	selector []byte,
+) (ExecutionResult, error) {
+	if !isMintingEnabled() {
+		return nil, newSupplyError(ErrMintingPaused, blockNumber, big.NewInt(BlockRewardAmount))
+	}
+
+	sst := GetGlobalSupplyTracker()
+	st := sst.tracker
+
+	st.mutex.Lock()
+	reservation, err := sst.mintRewardsLocked(nil, rewardMint{
+		firstBlock: blockNumber,
+		block:      blockNumber,
+		blocks:     1,
+		reward:     big.NewInt(BlockRewardAmount),
+		reserve:    true,
+	})
+	st.mutex.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	reward := reservation.Minted
+	if reward.Sign() == 0 {
+		if reservation.CapReached {
+			notifyCapReached(blockNumber)
+		}
+
+		return nil, nil
+	}
+
+	transition.AddBalance(contract, reward)
+
+	var notifyErr error
+
+	result := transition.Call2(contracts.SystemCaller, contract, selector, big.NewInt(0), mintNotifyGasLimit)
+	if result.Failed() {
+		err := transition.SubBalance(contract, reward)
+		if err == nil {
+			st.releaseReservation(blockNumber, reward)
+
+			return result, fmt.Errorf("%w: %v", ErrMintNotifyFailed, result.GetErr())
+		}
+
+		// The credit stays, so the mint must be accounted for
+		notifyErr = fmt.Errorf("%w: reverting credit: %v", ErrMintNotifyFailed, err)
+	}
+
+	if capReached := st.commitReservation(blockNumber, reward, contract); capReached {
+		notifyCapReached(blockNumber)
+	}
+
+	if notifyErr != nil {
+		return result, notifyErr
+	}
+
+	getSupplyLogger().Debug("minted block reward to contract", "block", blockNumber,
+		"reward", FormatAZE(reward), "contract", contract)
+
+	return result, nil
+}
+
+// DistributeTxFeesToValidator distributes transaction fees: 50% to owner, 50% to block producer.
+// A zero owner or producer address is rejected with ErrZeroAddressRecipient unless
+// FeeConfig.BurnZeroAddressFees is set. A share destined to the zero address under
+// that option, or to a recipient paused through FeeConfig, is not credited and is
+// staged as a burn. The burns and the fee ledger records are staged for txHash in
+// the block with blockHash and only recorded by CommitBlockFeeEffects once that
+// block is inserted.
 func DistributeTxFeesToValidator(
 	txn interface{ AddBalance(types.Address, *big.Int) },
 	totalFees *big.Int,
 	ownerAddress types.Address,
 	blockProducerAddress types.Address,
+	blockNumber uint64,
+	blockHash types.Hash,
+	txHash types.Hash,
 ) error {
 	if totalFees == nil || totalFees.Cmp(big.NewInt(0)) == 0 {
 		return nil
 	}
 
+	feeConfig := GetFeeConfig()
+
+	if !feeConfig.BurnZeroAddressFees {
+		if ownerAddress == types.ZeroAddress {
+			return fmt.Errorf("%w: owner address at block %d", ErrZeroAddressRecipient, blockNumber)
+		}
+
+		if blockProducerAddress == types.ZeroAddress {
+			return fmt.Errorf("%w: block producer address at block %d", ErrZeroAddressRecipient, blockNumber)
+		}
+	}
+
 	// Split fees: 50% to owner, 50% to block producer (validator)
 	ownerFee := new(big.Int).Div(totalFees, big.NewInt(2))
+
+	// Raise the owner's share to the floor, capped at the total fees
+	if floor := feeConfig.OwnerFeeFloor; floor != nil && ownerFee.Cmp(floor) < 0 {
+		if totalFees.Cmp(floor) > 0 {
+			ownerFee.Set(floor)
+		} else {
+			ownerFee.Set(totalFees)
+		}
+	}
+
 	validatorFee := new(big.Int).Sub(totalFees, ownerFee)
 
+	// Withhold the shares routed to the zero address or to a paused recipient
+	zeroAddressFee := big.NewInt(0)
+	pausedFee := big.NewInt(0)
+	payOwner, payProducer := true, true
+
+	switch {
+	case ownerAddress == types.ZeroAddress:
+		zeroAddressFee.Add(zeroAddressFee, ownerFee)
+		payOwner = false
+	case feeConfig.PauseOwnerPayout:
+		pausedFee.Add(pausedFee, ownerFee)
+		payOwner = false
+	}
+
+	switch {
+	case blockProducerAddress == types.ZeroAddress:
+		zeroAddressFee.Add(zeroAddressFee, validatorFee)
+		payProducer = false
+	case feeConfig.PauseProducerPayout:
+		pausedFee.Add(pausedFee, validatorFee)
+		payProducer = false
+	}
+
+	effects := &txFeeEffects{
+		zeroAddressBurn: zeroAddressFee,
+		pausedBurn:      pausedFee,
+	}
+
 	// Transfer fees
-	txn.AddBalance(ownerAddress, ownerFee)
-	txn.AddBalance(blockProducerAddress, validatorFee)
+	if payOwner {
+		txn.AddBalance(ownerAddress, ownerFee)
+		effects.payouts = append(effects.payouts, feePayout{addr: ownerAddress, amount: ownerFee, kind: ownerFeePayout})
+	}
+
+	if payProducer {
+		txn.AddBalance(blockProducerAddress, validatorFee)
+		effects.payouts = append(effects.payouts,
+			feePayout{addr: blockProducerAddress, amount: validatorFee, kind: producerFeePayout})
+	}
+
+	stageTxFeeEffects(blockNumber, blockHash, txHash, effects)
+
+	return nil
+}
+
+// DistributeTxFeesToTreasury routes transaction fees into a treasury contract by
+// calling its deposit function with the given selector from the system caller,
+// sending the fees as the call value. The system caller is credited with the fees
+// first so it can fund the call. When the call fails the funding is removed from
+// the system caller and the fees are credited to the treasury with AddBalance
+// instead; if the funding cannot be removed the treasury is not credited and the
+// error is returned. The treasury's fee ledger record is staged for txHash in the
+// block with blockHash, like the ones of DistributeTxFeesToValidator. The call's ExecutionResult is
+// returned, nil when there are no fees.
+func DistributeTxFeesToTreasury(
+	transition StateTransition,
+	totalFees *big.Int,
+	treasury types.Address,
+	depositSelector []byte,
+	blockNumber uint64,
+	blockHash types.Hash,
+	txHash types.Hash,
+) (ExecutionResult, error) {
+	if totalFees == nil || totalFees.Sign() <= 0 {
+		return nil, nil
+	}
+
+	transition.AddBalance(contracts.SystemCaller, totalFees)
+
+	result := transition.Call2(contracts.SystemCaller, treasury, depositSelector, totalFees, treasuryDepositGasLimit)
+	if result.Failed() {
+		getSupplyLogger().Warn("treasury deposit failed, crediting fees directly", "treasury", treasury,
+			"fees", FormatAZE(totalFees), "err", result.GetErr())
+
+		if err := transition.SubBalance(contracts.SystemCaller, totalFees); err != nil {
+			return result, fmt.Errorf("failed to revert system caller funding: %w", err)
+		}
+
+		transition.AddBalance(treasury, totalFees)
+	}
+
+	stageTxFeeEffects(blockNumber, blockHash, txHash, &txFeeEffects{
+		zeroAddressBurn: big.NewInt(0),
+		pausedBurn:      big.NewInt(0),
+		payouts:         []feePayout{{addr: treasury, amount: new(big.Int).Set(totalFees), kind: treasuryFeePayout}},
+	})
+
+	return result, nil
+}
+
+// WeightedRecipient is a fee recipient with a relative weight
+type WeightedRecipient struct {
+	Address types.Address
+	Weight  uint64
+}
+
+// DistributeTxFeesMultiOwner distributes transaction fees between several owner addresses
+// and the block producer. The producer receives producerBps basis points of the fees and
+// the rest is split among the owners by weight, with rounding dust going to the first owner.
+func DistributeTxFeesMultiOwner(
+	txn interface{ AddBalance(types.Address, *big.Int) },
+	totalFees *big.Int,
+	owners []WeightedRecipient,
+	producer types.Address,
+	producerBps uint64,
+) error {
+	if totalFees == nil || totalFees.Cmp(big.NewInt(0)) == 0 {
+		return nil
+	}
+
+	if producerBps > maxBasisPoints {
+		return fmt.Errorf("producer share %d bps exceeds %d bps", producerBps, maxBasisPoints)
+	}
+
+	if producer == types.ZeroAddress {
+		return fmt.Errorf("block producer cannot be zero address")
+	}
+
+	if len(owners) == 0 {
+		return fmt.Errorf("at least one owner recipient is required")
+	}
+
+	totalWeight := big.NewInt(0)
+
+	for _, owner := range owners {
+		if owner.Address == types.ZeroAddress {
+			return fmt.Errorf("owner recipient cannot be zero address")
+		}
+
+		totalWeight.Add(totalWeight, new(big.Int).SetUint64(owner.Weight))
+	}
+
+	if totalWeight.Sign() == 0 {
+		return fmt.Errorf("owner weights must sum to a positive value")
+	}
+
+	producerFee := new(big.Int).Mul(totalFees, new(big.Int).SetUint64(producerBps))
+	producerFee.Div(producerFee, big.NewInt(maxBasisPoints))
+	ownerShare := new(big.Int).Sub(totalFees, producerFee)
+
+	payouts := make([]*big.Int, len(owners))
+	distributed := big.NewInt(0)
+
+	for i, owner := range owners {
+		payouts[i] = new(big.Int).Mul(ownerShare, new(big.Int).SetUint64(owner.Weight))
+		payouts[i].Div(payouts[i], totalWeight)
+		distributed.Add(distributed, payouts[i])
+	}
+
+	// Assign the rounding dust to the first recipient
+	payouts[0].Add(payouts[0], new(big.Int).Sub(ownerShare, distributed))
+
+	for i, owner := range owners {
+		if payouts[i].Sign() > 0 {
+			txn.AddBalance(owner.Address, payouts[i])
+		}
+	}
+
+	if producerFee.Sign() > 0 {
+		txn.AddBalance(producer, producerFee)
+	}
 
 	return nil
 }
 
+// DistributeTxFeesByUptime distributes a fee share among validators proportionally
+// to their uptime counts. Validators with zero uptime receive nothing and the
+// rounding remainder goes to the validator with the highest uptime.
+func DistributeTxFeesByUptime(
+	txn interface{ AddBalance(types.Address, *big.Int) },
+	share *big.Int,
+	uptime map[types.Address]uint64,
+) error {
+	if share == nil || share.Sign() == 0 {
+		return nil
+	}
+
+	if share.Sign() < 0 {
+		return ErrInvalidAmount
+	}
+
+	// Iterate in address order so the distribution is deterministic
+	addresses := make([]types.Address, 0, len(uptime))
+	totalUptime := big.NewInt(0)
+
+	for addr, count := range uptime {
+		if count == 0 {
+			continue
+		}
+
+		addresses = append(addresses, addr)
+		totalUptime.Add(totalUptime, new(big.Int).SetUint64(count))
+	}
+
+	if len(addresses) == 0 {
+		return fmt.Errorf("no validator uptime recorded")
+	}
+
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+	})
+
+	topValidator := addresses[0]
+	payouts := make(map[types.Address]*big.Int, len(addresses))
+	distributed := big.NewInt(0)
+
+	for _, addr := range addresses {
+		payout := new(big.Int).Mul(share, new(big.Int).SetUint64(uptime[addr]))
+		payout.Div(payout, totalUptime)
+
+		payouts[addr] = payout
+		distributed.Add(distributed, payout)
+
+		if uptime[addr] > uptime[topValidator] {
+			topValidator = addr
+		}
+	}
+
+	// Assign the rounding remainder to the highest-uptime validator
+	payouts[topValidator].Add(payouts[topValidator], new(big.Int).Sub(share, distributed))
+
+	for _, addr := range addresses {
+		if payouts[addr].Sign() > 0 {
+			txn.AddBalance(addr, payouts[addr])
+		}
+	}
+
+	return nil
+}
+
+// SlashValidator deducts amount from the validator's balance and records it as a burn
+// in the global supply tracker. The balance check, the burn and the deduction happen
+// under the tracker lock so concurrent supply changes cannot interleave.
+func SlashValidator(txn interface {
+	GetBalance(types.Address) *big.Int
+	SubBalance(types.Address, *big.Int) error
+}, validator types.Address, amount *big.Int, blockNumber uint64) error {
+	if amount == nil || amount.Sign() <= 0 {
+		return ErrInvalidAmount
+	}
+
+	st := GetGlobalSupplyTracker().tracker
+
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	if balance := txn.GetBalance(validator); balance.Cmp(amount) < 0 {
+		return fmt.Errorf("%w: validator %s has %s wei, slash requires %s wei",
+			ErrInsufficientStake, validator, balance.String(), amount.String())
+	}
+
+	if st.getCurrentSupply().Cmp(amount) < 0 {
+		return newSupplyError(ErrInsufficientSupply, blockNumber, amount)
+	}
+
+	if err := txn.SubBalance(validator, amount); err != nil {
+		return err
+	}
+
+	if err := st.burnLocked(new(big.Int).Set(amount), blockNumber, consensusEngineCaller, ReasonSlash, nil); err != nil {
+		return err
+	}
+
+	getSupplyLogger().Debug("slashed validator", "block", blockNumber, "amount", FormatAZE(amount),
+		"validator", validator)
+
+	return nil
+}
+
+// SetBurnToDeadAddress controls whether BurnFromAccount moves burned tokens to
+// BurnAddress instead of only deducting them from the account
+func SetBurnToDeadAddress(enabled bool) {
+	burnToDeadLock.Lock()
+	defer burnToDeadLock.Unlock()
+
+	burnToDeadAddress = enabled
+}
+
+// BurnFromAccount deducts amount from the account's balance and records it as a
+// burn in the global supply tracker, rejecting the burn if the balance is
+// insufficient. With SetBurnToDeadAddress enabled the tokens are moved to
+// BurnAddress instead of disappearing from the state.
+func BurnFromAccount(txn interface {
+	AddBalance(types.Address, *big.Int)
+	GetBalance(types.Address) *big.Int
+	SubBalance(types.Address, *big.Int) error
+}, addr types.Address, amount *big.Int, blockNumber uint64) error {
+	if amount == nil || amount.Sign() <= 0 {
+		return ErrInvalidAmount
+	}
+
+	burnToDeadLock.RLock()
+	toDeadAddress := burnToDeadAddress
+	burnToDeadLock.RUnlock()
+
+	st := GetGlobalSupplyTracker().tracker
+
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	if balance := txn.GetBalance(addr); balance.Cmp(amount) < 0 {
+		return fmt.Errorf("%w: account %s has %s wei, burn requires %s wei",
+			ErrInsufficientBalance, addr, balance.String(), amount.String())
+	}
+
+	if st.getCurrentSupply().Cmp(amount) < 0 {
+		return newSupplyError(ErrInsufficientSupply, blockNumber, amount)
+	}
+
+	if err := txn.SubBalance(addr, amount); err != nil {
+		return err
+	}
+
+	if toDeadAddress {
+		txn.AddBalance(BurnAddress, amount)
+	}
+
+	return st.burnLocked(new(big.Int).Set(amount), blockNumber, consensusEngineCaller, ReasonAccountBurn, nil)
+}
+
+// SetStakingContractAddress sets the address the staking contract is expected at,
+// for chains that predeploy it somewhere other than the default 0x...1001
+func SetStakingContractAddress(addr types.Address) {
+	stakingContractLock.Lock()
+	defer stakingContractLock.Unlock()
+
+	StakingContractAddress = addr
+}
+
 // CheckStakingContractDeployed checks if the staking contract is deployed
 func CheckStakingContractDeployed(
 	transition interface{ AccountExists(types.Address) bool },
 ) bool {
-	stakingAddress := types.StringToAddress("0x0000000000000000000000000000000000001001")
+	stakingContractLock.RLock()
+	stakingAddress := StakingContractAddress
+	stakingContractLock.RUnlock()
+
 	return transition.AccountExists(stakingAddress)
 }
 
@@ -222,13 +907,186 @@ func GetCurrentSupply() *big.Int {
 	return supplyTracker.GetCurrentSupply()
 }
 
+// GetMaxSupply returns the maximum supply limit
+func GetMaxSupply() *big.Int {
+	return getMaxSupply()
+}
+
 // GetSupplyAuditLog gets the supply audit log for transparency
 func GetSupplyAuditLog() []SupplyAuditLog {
 	supplyTracker := GetGlobalSupplyTracker()
 	return supplyTracker.GetAuditLog()
 }
 
+// GetRewardsByRecipient returns the total reward the global supply tracker minted to the address
+func GetRewardsByRecipient(addr types.Address) *big.Int {
+	return GetGlobalSupplyTracker().GetRewardsByRecipient(addr)
+}
+
+// ReconcileSupply compares the global supply tracker against the deterministic
+// block formula and returns an error describing any divergence
+func ReconcileSupply(blockNumber uint64) error {
+	return GetGlobalSupplyTracker().ReconcileSupply(blockNumber)
+}
+
 // GetCurrentSupplyAtBlock returns the deterministic supply at a given block
 func GetCurrentSupplyAtBlock(blockNumber uint64) *big.Int {
 	return getCurrentSupplyFromBlockNumber(blockNumber)
 }
+
+// GetSupplyDelta returns the signed net supply change from fromBlock to toBlock.
+// The audit log of the global tracker is summed for the blocks it covers, so a
+// net burn shows up as a negative delta; blocks outside the log use the
+// deterministic formula, clamped to the max supply. A reversed range negates the result.
+func GetSupplyDelta(fromBlock, toBlock uint64) *big.Int {
+	if fromBlock > toBlock {
+		return new(big.Int).Neg(GetSupplyDelta(toBlock, fromBlock))
+	}
+
+	if fromBlock == toBlock {
+		return big.NewInt(0)
+	}
+
+	maxSupply := getMaxSupply()
+
+	clamped := func(blockNumber uint64) *big.Int {
+		supply := getCurrentSupplyFromBlockNumber(blockNumber)
+		if supply.Cmp(maxSupply) > 0 {
+			return maxSupply
+		}
+
+		return supply
+	}
+
+	formula := func(from, to uint64) *big.Int {
+		return new(big.Int).Sub(clamped(to), clamped(from))
+	}
+
+	return GetGlobalSupplyTracker().tracker.netChangeBetween(fromBlock, toBlock, formula)
+}
+
+// ProjectedSupply describes the deterministic supply at a (possibly future) block
+type ProjectedSupply struct {
+	Block uint64
+	// Supply is the projected supply in wei, clamped to the max supply
+	Supply *big.Int
+	// SupplyAZE is Supply rendered in whole tokens
+	SupplyAZE string
+	// PastCap is true when the max supply is reached at or before Block
+	PastCap bool
+	// CapBlock is the first block at which the max supply is reached, only set when PastCap is true
+	CapBlock uint64
+}
+
+// ProjectSupplyAt projects the supply at the given block from the genesis total
+// and the fixed block reward, without waiting for the chain to reach it
+func ProjectSupplyAt(blockNumber uint64) ProjectedSupply {
+	maxSupply := getMaxSupply()
+	supply := getCurrentSupplyFromBlockNumber(blockNumber)
+
+	projection := ProjectedSupply{Block: blockNumber}
+
+	if capBlock := BlockWhereCapReached(); capBlock != CapNeverReached && capBlock <= blockNumber {
+		projection.PastCap = true
+		projection.CapBlock = capBlock
+	}
+
+	if supply.Cmp(maxSupply) > 0 {
+		supply = maxSupply
+	}
+
+	projection.Supply = supply
+	projection.SupplyAZE = FormatAZE(supply)
+
+	return projection
+}
+
+// RemainingMintableAtBlock returns how much can still be minted after the given
+// block according to the deterministic supply formula, or zero past the cap
+func RemainingMintableAtBlock(blockNumber uint64) *big.Int {
+	return remainingMintable(getCurrentSupplyFromBlockNumber(blockNumber), getMaxSupply())
+}
+
+// CanMintAtBlock reports whether MintBlockReward would credit anything at the
+// given block, i.e. whether the deterministic supply is still below the cap. It
+// uses the same clamping as MintBlockReward, so a partial final reward reports
+// true. Pausing is not considered.
+func CanMintAtBlock(blockNumber uint64) bool {
+	return computeMintableReward(
+		getCurrentSupplyFromBlockNumber(blockNumber),
+		big.NewInt(BlockRewardAmount),
+		getMaxSupply(),
+	).Sign() > 0
+}
+
+// SupplyPercentOfCapAtBlock returns the deterministic supply at the given block as
+// a percentage of the max supply, clamped to 100
+func SupplyPercentOfCapAtBlock(blockNumber uint64) float64 {
+	return percentOfCap(getCurrentSupplyFromBlockNumber(blockNumber), getMaxSupply())
+}
+
+// BlockWhereCapReached returns the first block at which the genesis total, net of
+// the genesis burns, plus the rewards emitted under the emission schedule reaches
+// the max supply. CapNeverReached is returned if the cap is unreachable.
+func BlockWhereCapReached() uint64 {
+	capBlock, reachable := scheduleCapBlock(getCirculatingGenesisTotal(), defaultEmissionSchedule, getMaxSupply())
+	if !reachable {
+		return CapNeverReached
+	}
+
+	return capBlock
+}
+
+// InflationRateAtBlock returns the annualized inflation rate at the given block as a
+// percentage: the scheduled reward of the next block * blocksPerYear / supply, with
+// the supply taken from the emission schedule. Zero is returned once the cap is
+// reached, since nothing more is minted, and when the supply is zero.
+func InflationRateAtBlock(blockNumber uint64, blocksPerYear uint64) float64 {
+	if capBlock := BlockWhereCapReached(); capBlock != CapNeverReached && blockNumber >= capBlock {
+		return 0
+	}
+
+	supply := SupplyFromSchedule(blockNumber, defaultEmissionSchedule)
+	if supply.Sign() == 0 {
+		return 0
+	}
+
+	yearlyEmission := new(big.Int).Mul(
+		rewardAtBlock(blockNumber+1, defaultEmissionSchedule),
+		new(big.Int).SetUint64(blocksPerYear),
+	)
+
+	rate := new(big.Float).Quo(new(big.Float).SetInt(yearlyEmission), new(big.Float).SetInt(supply))
+	percent, _ := rate.Mul(rate, big.NewFloat(100)).Float64()
+
+	return percent
+}
+
+// scheduleCapBlock returns the first block where genesisTotal plus the rewards
+// emitted under the schedule reaches maxSupply, and false if it is never reached
+// within the uint64 range. The emission only grows with the block number, so the
+// block is found by binary search.
+func scheduleCapBlock(genesisTotal *big.Int, schedule []EmissionEra, maxSupply *big.Int) (uint64, bool) {
+	remaining := new(big.Int).Sub(maxSupply, genesisTotal)
+	if remaining.Sign() <= 0 {
+		return 0, true
+	}
+
+	if emittedFromSchedule(math.MaxUint64, schedule).Cmp(remaining) < 0 {
+		return 0, false
+	}
+
+	low, high := uint64(1), uint64(math.MaxUint64)
+
+	for low < high {
+		mid := low + (high-low)/2
+
+		if emittedFromSchedule(mid, schedule).Cmp(remaining) >= 0 {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+
+	return low, true
+}
@@ -0,0 +1,1444 @@
+package staking
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+// mockTxn records balance changes made through the txn interfaces
+type mockTxn struct {
+	balances map[types.Address]*big.Int
+}
+
+func newMockTxn() *mockTxn {
+	return &mockTxn{
+		balances: make(map[types.Address]*big.Int),
+	}
+}
+
+// testBlockHash returns the hash of the inserted block at the given height in tests
+func testBlockHash(number uint64) types.Hash {
+	return types.BytesToHash(new(big.Int).SetUint64(number).Bytes())
+}
+
+func (m *mockTxn) AddBalance(addr types.Address, amount *big.Int) {
+	m.GetBalance(addr).Add(m.balances[addr], amount)
+}
+
+func (m *mockTxn) GetBalance(addr types.Address) *big.Int {
+	if _, ok := m.balances[addr]; !ok {
+		m.balances[addr] = big.NewInt(0)
+	}
+
+	return m.balances[addr]
+}
+
+func (m *mockTxn) SubBalance(addr types.Address, amount *big.Int) error {
+	m.GetBalance(addr).Sub(m.balances[addr], amount)
+
+	return nil
+}
+
+func TestDistributeTxFeesByUptime(t *testing.T) {
+	var (
+		validatorA = types.StringToAddress("0x1")
+		validatorB = types.StringToAddress("0x2")
+		validatorC = types.StringToAddress("0x3")
+		offline    = types.StringToAddress("0x4")
+	)
+
+	txn := newMockTxn()
+	share := big.NewInt(1000)
+	uptime := map[types.Address]uint64{
+		validatorA: 50,
+		validatorB: 30,
+		validatorC: 20,
+		offline:    0,
+	}
+
+	if err := DistributeTxFeesByUptime(txn, share, uptime); err != nil {
+		t.Fatalf("Failed to distribute fees: %v", err)
+	}
+
+	expected := map[types.Address]int64{
+		validatorA: 500,
+		validatorB: 300,
+		validatorC: 200,
+		offline:    0,
+	}
+
+	for addr, amount := range expected {
+		if txn.GetBalance(addr).Cmp(big.NewInt(amount)) != 0 {
+			t.Errorf("Expected %s to receive %d, got %s", addr, amount, txn.GetBalance(addr).String())
+		}
+	}
+
+	// 1000 split 1:5:1 leaves 2 wei of dust which goes to the highest-uptime validator
+	txn = newMockTxn()
+	uptime = map[types.Address]uint64{
+		validatorA: 1,
+		validatorB: 5,
+		validatorC: 1,
+	}
+
+	if err := DistributeTxFeesByUptime(txn, share, uptime); err != nil {
+		t.Fatalf("Failed to distribute fees: %v", err)
+	}
+
+	total := big.NewInt(0)
+	for _, balance := range txn.balances {
+		total.Add(total, balance)
+	}
+
+	if total.Cmp(share) != 0 {
+		t.Errorf("Expected distributed total %s, got %s", share.String(), total.String())
+	}
+
+	if txn.GetBalance(validatorB).Cmp(big.NewInt(716)) != 0 {
+		t.Errorf("Expected highest-uptime validator to receive 716, got %s", txn.GetBalance(validatorB).String())
+	}
+}
+
+func TestDistributeTxFeesMultiOwner(t *testing.T) {
+	var (
+		ownerA   = types.StringToAddress("0xa")
+		ownerB   = types.StringToAddress("0xb")
+		ownerC   = types.StringToAddress("0xc")
+		producer = types.StringToAddress("0xd")
+	)
+
+	txn := newMockTxn()
+	owners := []WeightedRecipient{
+		{Address: ownerA, Weight: 1},
+		{Address: ownerB, Weight: 1},
+		{Address: ownerC, Weight: 1},
+	}
+
+	// 30% to the producer leaves 701 wei for three equally weighted owners
+	if err := DistributeTxFeesMultiOwner(txn, big.NewInt(1001), owners, producer, 3000); err != nil {
+		t.Fatalf("Failed to distribute fees: %v", err)
+	}
+
+	expected := map[types.Address]int64{
+		ownerA:   235,
+		ownerB:   233,
+		ownerC:   233,
+		producer: 300,
+	}
+
+	for addr, amount := range expected {
+		if txn.GetBalance(addr).Cmp(big.NewInt(amount)) != 0 {
+			t.Errorf("Expected %s to receive %d, got %s", addr, amount, txn.GetBalance(addr).String())
+		}
+	}
+
+	invalid := [][]WeightedRecipient{
+		nil,
+		{{Address: ownerA, Weight: 0}},
+		{{Address: ownerA, Weight: 1}, {Address: types.ZeroAddress, Weight: 1}},
+	}
+
+	for i, owners := range invalid {
+		if err := DistributeTxFeesMultiOwner(newMockTxn(), big.NewInt(100), owners, producer, 5000); err == nil {
+			t.Errorf("Expected invalid owners %d to be rejected", i)
+		}
+	}
+}
+
+func TestDistributeTxFeesToValidatorRejectsZeroProducer(t *testing.T) {
+	InitializeSupplyTracker(big.NewInt(1000000))
+	defer InitializeSupplyTracker(big.NewInt(0))
+
+	owner := types.StringToAddress(testOwnerAddress)
+	txn := newMockTxn()
+
+	err := DistributeTxFeesToValidator(txn, big.NewInt(1001), owner, types.ZeroAddress, 7, testBlockHash(7), types.StringToHash("0x1"))
+	if !errors.Is(err, ErrZeroAddressRecipient) {
+		t.Fatalf("Expected ErrZeroAddressRecipient, got %v", err)
+	}
+
+	if txn.GetBalance(owner).Sign() != 0 {
+		t.Errorf("Expected owner to receive nothing, got %s", txn.GetBalance(owner).String())
+	}
+
+	if len(GetSupplyAuditLog()) != 0 {
+		t.Errorf("Expected no audit entries, got %d", len(GetSupplyAuditLog()))
+	}
+}
+
+func TestDistributeTxFeesToValidatorZeroProducerBurns(t *testing.T) {
+	InitializeSupplyTracker(big.NewInt(1000000))
+	defer InitializeSupplyTracker(big.NewInt(0))
+
+	SetFeeConfig(FeeConfig{BurnZeroAddressFees: true})
+	defer SetFeeConfig(FeeConfig{})
+
+	resetStagedTxFees()
+	defer resetStagedTxFees()
+
+	var (
+		owner  = types.StringToAddress(testOwnerAddress)
+		txHash = types.StringToHash("0x1")
+	)
+
+	txn := newMockTxn()
+
+	if err := DistributeTxFeesToValidator(txn, big.NewInt(1001), owner, types.ZeroAddress, 7, testBlockHash(7), txHash); err != nil {
+		t.Fatalf("Failed to distribute fees: %v", err)
+	}
+
+	// The burn is only recorded once the block is committed
+	if len(GetSupplyAuditLog()) != 0 {
+		t.Fatalf("Expected no audit entries before commit, got %d", len(GetSupplyAuditLog()))
+	}
+
+	if err := CommitBlockFeeEffects(7, testBlockHash(7)); err != nil {
+		t.Fatalf("Failed to commit fee effects: %v", err)
+	}
+
+	if txn.GetBalance(owner).Cmp(big.NewInt(500)) != 0 {
+		t.Errorf("Expected owner to receive 500, got %s", txn.GetBalance(owner).String())
+	}
+
+	if txn.GetBalance(types.ZeroAddress).Sign() != 0 {
+		t.Errorf("Expected zero address to receive nothing, got %s", txn.GetBalance(types.ZeroAddress).String())
+	}
+
+	auditLog := GetSupplyAuditLog()
+	if len(auditLog) != 1 {
+		t.Fatalf("Expected 1 audit log entry, got %d", len(auditLog))
+	}
+
+	burn := auditLog[0]
+	if burn.Type != ChangeBurn || burn.Amount.Cmp(big.NewInt(501)) != 0 ||
+		burn.BlockNumber != 7 || burn.Reason != ReasonZeroAddressFee {
+		t.Errorf("Unexpected burn entry: %+v", burn)
+	}
+}
+
+func TestSetSoftCap(t *testing.T) {
+	defaultMax := getMaxSupply()
+	defer func() {
+		if err := SetSoftCap(nil); err != nil {
+			t.Fatalf("Failed to reset soft cap: %v", err)
+		}
+
+		if err := SetMaxSupply(defaultMax); err != nil {
+			t.Fatalf("Failed to restore max supply: %v", err)
+		}
+	}()
+
+	if GetSoftCap().Cmp(defaultMax) != 0 {
+		t.Fatal("Expected the soft cap to default to the max supply")
+	}
+
+	if err := SetSoftCap(new(big.Int).Add(defaultMax, big.NewInt(1))); !errors.Is(err, ErrInvalidMaxSupply) {
+		t.Errorf("Expected ErrInvalidMaxSupply for a soft cap above the max supply, got %v", err)
+	}
+
+	// Raise the hard cap to 200 and keep the nominal cap of 100 as the soft cap
+	if err := SetMaxSupply(big.NewInt(200)); err != nil {
+		t.Fatalf("Failed to set max supply: %v", err)
+	}
+
+	if err := SetSoftCap(big.NewInt(100)); err != nil {
+		t.Fatalf("Failed to set soft cap: %v", err)
+	}
+
+	var logs bytes.Buffer
+
+	SetSupplyLogger(hclog.New(&hclog.LoggerOptions{Output: &logs}))
+	defer SetSupplyLogger(nil)
+
+	tracker := NewSupplyTracker(big.NewInt(90))
+
+	// Minting into the warning band still succeeds, with a warning
+	if err := tracker.Mint(big.NewInt(50), 1, "consensus_engine"); err != nil {
+		t.Fatalf("Expected mint above the soft cap to succeed, got %v", err)
+	}
+
+	if !strings.Contains(logs.String(), "[WARN]  supply is above the soft cap") {
+		t.Errorf("Expected a soft cap warning through the supply logger, got %q", logs.String())
+	}
+
+	if err := tracker.Mint(big.NewInt(61), 2, "consensus_engine"); !errors.Is(err, ErrSupplyCapExceeded) {
+		t.Errorf("Expected ErrSupplyCapExceeded above the hard cap, got %v", err)
+	}
+
+	if got := tracker.GetTotalSupply(); got.Cmp(big.NewInt(140)) != 0 {
+		t.Errorf("Expected supply 140, got %s", got.String())
+	}
+
+	// A soft cap left above a lowered max supply is clamped to it
+	if err := SetMaxSupply(big.NewInt(80)); err != nil {
+		t.Fatalf("Failed to set max supply: %v", err)
+	}
+
+	if got := GetSoftCap(); got.Cmp(big.NewInt(80)) != 0 {
+		t.Errorf("Expected the soft cap clamped to 80, got %s", got.String())
+	}
+}
+
+func TestSetMaxSupply(t *testing.T) {
+	defaultMax := getMaxSupply()
+	defer func() {
+		SetGenesisAllocCache(nil)
+
+		if err := SetMaxSupply(defaultMax); err != nil {
+			t.Fatalf("Failed to restore max supply: %v", err)
+		}
+	}()
+
+	owner := types.StringToAddress(testOwnerAddress)
+	genesis := big.NewInt(5 * BlockRewardAmount)
+
+	SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{
+		owner: {Balance: genesis},
+	})
+
+	if err := SetMaxSupply(new(big.Int).Sub(genesis, big.NewInt(1))); !errors.Is(err, ErrInvalidMaxSupply) {
+		t.Fatalf("Expected ErrInvalidMaxSupply, got %v", err)
+	}
+
+	// Cap one and a half rewards above genesis
+	halfReward := big.NewInt(BlockRewardAmount / 2)
+	capped := new(big.Int).Add(genesis, big.NewInt(BlockRewardAmount))
+	capped.Add(capped, halfReward)
+
+	if err := SetMaxSupply(capped); err != nil {
+		t.Fatalf("Failed to set max supply: %v", err)
+	}
+
+	if getMaxSupply().Cmp(capped) != 0 {
+		t.Fatalf("Expected max supply %s, got %s", capped.String(), getMaxSupply().String())
+	}
+
+	txn := newMockTxn()
+
+	// Block 0 is at genesis supply and mints the full reward,
+	// block 1 only has half a reward left before the cap
+	for block := uint64(0); block <= 2; block++ {
+		if err := MintBlockReward(txn, block, owner); err != nil {
+			t.Fatalf("Failed to mint block reward: %v", err)
+		}
+	}
+
+	expected := new(big.Int).Add(big.NewInt(BlockRewardAmount), halfReward)
+	if txn.GetBalance(owner).Cmp(expected) != 0 {
+		t.Errorf("Expected owner balance %s, got %s", expected.String(), txn.GetBalance(owner).String())
+	}
+}
+
+func TestReconcileSupply(t *testing.T) {
+	owner := types.StringToAddress(testOwnerAddress)
+	genesis := new(big.Int).Mul(big.NewInt(100), big.NewInt(BlockRewardAmount))
+
+	SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{
+		owner: {Balance: genesis},
+	})
+	InitializeSupplyTracker(genesis)
+
+	defer func() {
+		SetGenesisAllocCache(nil)
+		InitializeSupplyTracker(big.NewInt(0))
+	}()
+
+	txn := newMockTxn()
+
+	for block := uint64(1); block <= 3; block++ {
+		if _, err := GetGlobalSupplyTracker().MintRewardWithCap(txn, block, owner); err != nil {
+			t.Fatalf("Failed to mint: %v", err)
+		}
+	}
+
+	if err := ReconcileSupply(3); err != nil {
+		t.Errorf("Expected supply to reconcile: %v", err)
+	}
+
+	if err := GetGlobalSupplyTracker().tracker.Burn(big.NewInt(1), 3, "consensus_engine"); err != nil {
+		t.Fatalf("Failed to burn: %v", err)
+	}
+
+	if err := ReconcileSupply(3); !errors.Is(err, ErrSupplyMismatch) {
+		t.Errorf("Expected ErrSupplyMismatch, got %v", err)
+	}
+}
+
+func TestGenesisAllocCacheConcurrentAccess(t *testing.T) {
+	defer SetGenesisAllocCache(nil)
+
+	alloc := map[types.Address]*chain.GenesisAccount{
+		types.StringToAddress(testOwnerAddress): {Balance: big.NewInt(BlockRewardAmount)},
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			SetGenesisAllocCache(alloc)
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			if total := getGenesisTotal(); total.Sign() < 0 {
+				t.Errorf("Unexpected genesis total %s", total.String())
+			}
+
+			_ = GetGenesisAllocCache()
+		}()
+	}
+
+	wg.Wait()
+
+	if getGenesisTotal().Cmp(big.NewInt(BlockRewardAmount)) != 0 {
+		t.Errorf("Expected genesis total %d, got %s", BlockRewardAmount, getGenesisTotal().String())
+	}
+}
+
+func TestSupplyTrackerConcurrentStress(t *testing.T) {
+	defer InitializeSupplyTracker(big.NewInt(0))
+
+	const (
+		workers    = 16
+		iterations = 200
+	)
+
+	initialSupply := new(big.Int).Mul(big.NewInt(1000), big.NewInt(BlockRewardAmount))
+	InitializeSupplyTracker(initialSupply)
+
+	sst := GetGlobalSupplyTracker()
+	amount := big.NewInt(3)
+
+	var (
+		wg      sync.WaitGroup
+		countMu sync.Mutex
+		mints   int64
+		burns   int64
+	)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func(w int) {
+			defer wg.Done()
+
+			for i := 0; i < iterations; i++ {
+				block := uint64(w*iterations + i)
+
+				switch i % 4 {
+				case 0:
+					if err := sst.MintBlockReward(amount, block); err == nil {
+						countMu.Lock()
+						mints++
+						countMu.Unlock()
+					}
+				case 1:
+					if err := sst.tracker.Burn(amount, block, "consensus_engine"); err == nil {
+						countMu.Lock()
+						burns++
+						countMu.Unlock()
+					}
+				case 2:
+					if err := UpdateSupplyTrackerWithTotalSupply(initialSupply); err != nil {
+						t.Errorf("Failed to update initial supply: %v", err)
+					}
+				default:
+					_ = GetGlobalSupplyTracker().GetCurrentSupply()
+					_ = sst.GetAuditLog()
+				}
+			}
+		}(w)
+	}
+
+	wg.Wait()
+
+	expected := new(big.Int).Set(initialSupply)
+	expected.Add(expected, new(big.Int).Mul(amount, big.NewInt(mints)))
+	expected.Sub(expected, new(big.Int).Mul(amount, big.NewInt(burns)))
+
+	if got := sst.GetCurrentSupply(); got.Cmp(expected) != 0 {
+		t.Errorf("Expected final supply %s, got %s", expected.String(), got.String())
+	}
+
+	if mints != workers*iterations/4 || burns != workers*iterations/4 {
+		t.Errorf("Expected every operation to succeed, got %d mints and %d burns", mints, burns)
+	}
+}
+
+func TestSlashValidator(t *testing.T) {
+	defer InitializeSupplyTracker(big.NewInt(0))
+
+	InitializeSupplyTracker(big.NewInt(1000))
+
+	validator := types.StringToAddress("0x1")
+	txn := newMockTxn()
+	txn.AddBalance(validator, big.NewInt(100))
+
+	if err := SlashValidator(txn, validator, big.NewInt(40), 1); err != nil {
+		t.Fatalf("Failed to slash: %v", err)
+	}
+
+	if got := txn.GetBalance(validator); got.Cmp(big.NewInt(60)) != 0 {
+		t.Errorf("Expected validator balance 60, got %s", got.String())
+	}
+
+	if got := GetCurrentSupply(); got.Cmp(big.NewInt(960)) != 0 {
+		t.Errorf("Expected supply 960, got %s", got.String())
+	}
+
+	if err := SlashValidator(txn, validator, big.NewInt(61), 2); !errors.Is(err, ErrInsufficientStake) {
+		t.Errorf("Expected ErrInsufficientStake, got %v", err)
+	}
+
+	if got := GetCurrentSupply(); got.Cmp(big.NewInt(960)) != 0 {
+		t.Errorf("Expected rejected slash to leave supply at 960, got %s", got.String())
+	}
+
+	log := GetSupplyAuditLog()
+	if len(log) != 1 || log[0].Type != ChangeBurn || log[0].Reason != ReasonSlash {
+		t.Errorf("Expected a single slash burn entry, got %+v", log)
+	}
+}
+
+func TestGenesisAllocBreakdown(t *testing.T) {
+	defer SetGenesisAllocCache(nil)
+
+	var (
+		small = types.StringToAddress("0x1")
+		large = types.StringToAddress("0x2")
+		tieA  = types.StringToAddress("0x3")
+		tieB  = types.StringToAddress("0x4")
+	)
+
+	SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{
+		types.ZeroAddress:            {Balance: big.NewInt(1000)},
+		small:                        {Balance: big.NewInt(5)},
+		large:                        {Balance: big.NewInt(50)},
+		tieB:                         {Balance: big.NewInt(20)},
+		tieA:                         {Balance: big.NewInt(20)},
+		types.StringToAddress("0x5"): {Code: []byte{0x1}},
+	})
+
+	breakdown, total := GenesisAllocBreakdown()
+
+	expected := []types.Address{large, tieA, tieB, small}
+	if len(breakdown) != len(expected) {
+		t.Fatalf("Expected %d entries, got %d", len(expected), len(breakdown))
+	}
+
+	for i, addr := range expected {
+		if breakdown[i].Address != addr {
+			t.Errorf("Expected entry %d to be %s, got %s", i, addr, breakdown[i].Address)
+		}
+	}
+
+	if total.Cmp(big.NewInt(95)) != 0 {
+		t.Errorf("Expected total 95, got %s", total.String())
+	}
+
+	if total.Cmp(getGenesisTotal()) != 0 {
+		t.Errorf("Expected breakdown total to match genesis total %s", getGenesisTotal().String())
+	}
+}
+
+func TestProjectSupplyAt(t *testing.T) {
+	defaultMax := getMaxSupply()
+	defer func() {
+		SetGenesisAllocCache(nil)
+
+		if err := SetMaxSupply(defaultMax); err != nil {
+			t.Fatalf("Failed to restore max supply: %v", err)
+		}
+	}()
+
+	reward := big.NewInt(BlockRewardAmount)
+	SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{
+		types.StringToAddress("0x1"): {Balance: new(big.Int).Mul(big.NewInt(10), reward)},
+	})
+
+	// Cap is reached 2.5 rewards after genesis, so at block 3
+	maxSupply := new(big.Int).Mul(big.NewInt(25), new(big.Int).Div(reward, big.NewInt(2)))
+	if err := SetMaxSupply(maxSupply); err != nil {
+		t.Fatalf("Failed to set max supply: %v", err)
+	}
+
+	projection := ProjectSupplyAt(2)
+	if projection.PastCap || projection.Supply.Cmp(new(big.Int).Mul(big.NewInt(12), reward)) != 0 {
+		t.Errorf("Unexpected projection at block 2: %+v", projection)
+	}
+
+	if projection.SupplyAZE != "12" {
+		t.Errorf("Expected 12 AZE, got %s", projection.SupplyAZE)
+	}
+
+	projection = ProjectSupplyAt(100)
+	if !projection.PastCap || projection.CapBlock != 3 {
+		t.Errorf("Expected cap reached at block 3, got %+v", projection)
+	}
+
+	if projection.Supply.Cmp(getMaxSupply()) != 0 {
+		t.Errorf("Expected projection clamped to max supply, got %s", projection.Supply.String())
+	}
+}
+
+func TestRemainingMintable(t *testing.T) {
+	defaultMax := getMaxSupply()
+	defer func() {
+		SetGenesisAllocCache(nil)
+
+		if err := SetMaxSupply(defaultMax); err != nil {
+			t.Fatalf("Failed to restore max supply: %v", err)
+		}
+	}()
+
+	reward := big.NewInt(BlockRewardAmount)
+	SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{
+		types.StringToAddress("0x1"): {Balance: new(big.Int).Mul(big.NewInt(10), reward)},
+	})
+
+	if err := SetMaxSupply(new(big.Int).Mul(big.NewInt(15), reward)); err != nil {
+		t.Fatalf("Failed to set max supply: %v", err)
+	}
+
+	if got := RemainingMintableAtBlock(2); got.Cmp(new(big.Int).Mul(big.NewInt(3), reward)) != 0 {
+		t.Errorf("Expected 3 AZE remaining at block 2, got %s", got.String())
+	}
+
+	if got := RemainingMintableAtBlock(100); got.Sign() != 0 {
+		t.Errorf("Expected nothing remaining past the cap, got %s", got.String())
+	}
+
+	sst := NewSystemSupplyTracker(new(big.Int).Mul(big.NewInt(14), reward))
+	if got := sst.RemainingMintableSupplyAZE(); got != "1" {
+		t.Errorf("Expected 1 AZE remaining, got %s", got)
+	}
+
+	// Supply above the cap reports zero rather than a negative amount
+	sst = NewSystemSupplyTracker(new(big.Int).Mul(big.NewInt(20), reward))
+	if got := sst.RemainingMintableSupply(); got.Sign() != 0 {
+		t.Errorf("Expected zero remaining above the cap, got %s", got.String())
+	}
+}
+
+func TestCanMintAtBlockMatchesMintBlockReward(t *testing.T) {
+	defaultMax := getMaxSupply()
+	defer func() {
+		SetGenesisAllocCache(nil)
+
+		if err := SetMaxSupply(defaultMax); err != nil {
+			t.Fatalf("Failed to restore max supply: %v", err)
+		}
+	}()
+
+	reward := big.NewInt(BlockRewardAmount)
+	owner := types.StringToAddress(testOwnerAddress)
+
+	SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{
+		types.StringToAddress("0x1"): {Balance: new(big.Int).Mul(big.NewInt(10), reward)},
+	})
+
+	// A cap of 14.5 rewards above genesis leaves a partial reward at block 4
+	maxSupply := new(big.Int).Mul(big.NewInt(29), reward)
+	if err := SetMaxSupply(maxSupply.Div(maxSupply, big.NewInt(2))); err != nil {
+		t.Fatalf("Failed to set max supply: %v", err)
+	}
+
+	for block := uint64(0); block <= 8; block++ {
+		txn := newMockTxn()
+		if err := MintBlockReward(txn, block, owner); err != nil {
+			t.Fatalf("Failed to mint at block %d: %v", block, err)
+		}
+
+		minted := txn.GetBalance(owner).Sign() > 0
+		if got := CanMintAtBlock(block); got != minted {
+			t.Errorf("Block %d: CanMintAtBlock returned %v but MintBlockReward minted %v", block, got, minted)
+		}
+
+		if expected := block <= 4; minted != expected {
+			t.Errorf("Block %d: expected minting %v, got %v", block, expected, minted)
+		}
+	}
+}
+
+func TestSupplyPercentOfCap(t *testing.T) {
+	defaultMax := getMaxSupply()
+	defer func() {
+		SetGenesisAllocCache(nil)
+
+		if err := SetMaxSupply(defaultMax); err != nil {
+			t.Fatalf("Failed to restore max supply: %v", err)
+		}
+	}()
+
+	reward := big.NewInt(BlockRewardAmount)
+	SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{
+		types.StringToAddress("0x1"): {Balance: new(big.Int).Mul(big.NewInt(10), reward)},
+	})
+
+	if err := SetMaxSupply(new(big.Int).Mul(big.NewInt(40), reward)); err != nil {
+		t.Fatalf("Failed to set max supply: %v", err)
+	}
+
+	if got := SupplyPercentOfCapAtBlock(0); got != 25 {
+		t.Errorf("Expected 25%% at genesis, got %v", got)
+	}
+
+	if got := SupplyPercentOfCapAtBlock(1000); got != 100 {
+		t.Errorf("Expected percentage clamped to 100, got %v", got)
+	}
+
+	// One wei below a 1e27 cap still registers as just under 100%
+	if err := SetMaxSupply(new(big.Int).Exp(big.NewInt(10), big.NewInt(27), nil)); err != nil {
+		t.Fatalf("Failed to set max supply: %v", err)
+	}
+
+	sst := NewSystemSupplyTracker(new(big.Int).Sub(getMaxSupply(), big.NewInt(1)))
+	if got := sst.SupplyPercentOfCap(); got > 100 || got < 99.999999 {
+		t.Errorf("Expected just under 100%%, got %v", got)
+	}
+
+	sst = NewSystemSupplyTracker(new(big.Int).Div(getMaxSupply(), big.NewInt(8)))
+	if got := sst.SupplyPercentOfCap(); got != 12.5 {
+		t.Errorf("Expected 12.5%%, got %v", got)
+	}
+}
+
+func TestBlockWhereCapReached(t *testing.T) {
+	defaultMax := getMaxSupply()
+	defer func() {
+		SetGenesisAllocCache(nil)
+
+		if err := SetMaxSupply(defaultMax); err != nil {
+			t.Fatalf("Failed to restore max supply: %v", err)
+		}
+	}()
+
+	reward := big.NewInt(BlockRewardAmount)
+	SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{
+		types.StringToAddress("0x1"): {Balance: new(big.Int).Mul(big.NewInt(100), reward)},
+	})
+
+	// 100 AZE genesis + 7 blocks of 1 AZE hits a 107 AZE cap exactly
+	if err := SetMaxSupply(new(big.Int).Mul(big.NewInt(107), reward)); err != nil {
+		t.Fatalf("Failed to set max supply: %v", err)
+	}
+
+	if got := BlockWhereCapReached(); got != 7 {
+		t.Errorf("Expected cap reached at block 7, got %d", got)
+	}
+
+	// One extra wei needs one more block
+	if err := SetMaxSupply(new(big.Int).Add(new(big.Int).Mul(big.NewInt(107), reward), big.NewInt(1))); err != nil {
+		t.Fatalf("Failed to set max supply: %v", err)
+	}
+
+	if got := BlockWhereCapReached(); got != 8 {
+		t.Errorf("Expected cap reached at block 8, got %d", got)
+	}
+
+	zeroReward := []EmissionEra{{StartBlock: 1, Reward: big.NewInt(0)}}
+	if _, reachable := scheduleCapBlock(big.NewInt(0), zeroReward, big.NewInt(10)); reachable {
+		t.Error("Expected cap to be unreachable with a zero reward")
+	}
+
+	// Rewards of 4, 4, 2, 2, 1, 1 emit 14 in total
+	halving := []EmissionEra{{StartBlock: 1, Reward: big.NewInt(4), HalvingInterval: 2}}
+
+	for maxSupply, expected := range map[int64]uint64{8: 2, 11: 4, 14: 6} {
+		if got, reachable := scheduleCapBlock(big.NewInt(0), halving, big.NewInt(maxSupply)); !reachable || got != expected {
+			t.Errorf("Expected a %d cap reached at block %d, got %d", maxSupply, expected, got)
+		}
+	}
+
+	if _, reachable := scheduleCapBlock(big.NewInt(0), halving, big.NewInt(15)); reachable {
+		t.Error("Expected cap to be unreachable once the halvings run out")
+	}
+}
+
+func TestGetCurrentSupplyFromBlockNumberAboveMaxInt64(t *testing.T) {
+	defer SetGenesisAllocCache(nil)
+
+	SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{
+		types.StringToAddress("0x1"): {Balance: big.NewInt(5)},
+	})
+
+	blockNumber := uint64(math.MaxInt64) + 10
+
+	supply := getCurrentSupplyFromBlockNumber(blockNumber)
+	if supply.Sign() <= 0 {
+		t.Fatalf("Expected a positive supply, got %s", supply.String())
+	}
+
+	expected := new(big.Int).Mul(new(big.Int).SetUint64(blockNumber), big.NewInt(BlockRewardAmount))
+	expected.Add(expected, big.NewInt(5))
+
+	if supply.Cmp(expected) != 0 {
+		t.Errorf("Expected supply %s, got %s", expected.String(), supply.String())
+	}
+}
+
+func TestInflationRateAtBlock(t *testing.T) {
+	defaultMax := getMaxSupply()
+	defer func() {
+		SetGenesisAllocCache(nil)
+
+		if err := SetMaxSupply(defaultMax); err != nil {
+			t.Fatalf("Failed to restore max supply: %v", err)
+		}
+	}()
+
+	reward := big.NewInt(BlockRewardAmount)
+	SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{
+		types.StringToAddress("0x1"): {Balance: new(big.Int).Mul(big.NewInt(1000000), reward)},
+	})
+
+	if err := SetMaxSupply(new(big.Int).Mul(big.NewInt(2000000), reward)); err != nil {
+		t.Fatalf("Failed to set max supply: %v", err)
+	}
+
+	// 100000 AZE emitted per year over a supply of 1,000,000 + 100000 AZE
+	const blocksPerYear = 100000
+
+	expected := 100.0 * 100000 / 1100000
+	if got := InflationRateAtBlock(100000, blocksPerYear); math.Abs(got-expected) > 1e-9 {
+		t.Errorf("Expected inflation rate %f%%, got %f%%", expected, got)
+	}
+
+	// Cap is reached at block 1,000,000
+	if got := InflationRateAtBlock(1000000, blocksPerYear); got != 0 {
+		t.Errorf("Expected zero inflation once the cap is reached, got %f%%", got)
+	}
+}
+
+func TestInitializeFromGenesis(t *testing.T) {
+	defer func() {
+		SetGenesisAllocCache(nil)
+		InitializeSupplyTracker(big.NewInt(0))
+	}()
+
+	SetGenesisAllocCache(nil)
+
+	if err := InitializeFromGenesis(); !errors.Is(err, ErrGenesisNotLoaded) {
+		t.Errorf("Expected ErrGenesisNotLoaded, got %v", err)
+	}
+
+	SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{
+		types.StringToAddress("0x1"): {Balance: big.NewInt(300)},
+		types.StringToAddress("0x2"): {Balance: big.NewInt(200)},
+	})
+
+	if err := InitializeFromGenesis(); err != nil {
+		t.Fatalf("Failed to initialize from genesis: %v", err)
+	}
+
+	if got := GetCurrentSupply(); got.Cmp(big.NewInt(500)) != 0 {
+		t.Errorf("Expected initial supply 500, got %s", got.String())
+	}
+}
+
+func TestDistributeTxFeesToValidatorPausedPayouts(t *testing.T) {
+	defer func() {
+		SetFeeConfig(FeeConfig{})
+		InitializeSupplyTracker(big.NewInt(0))
+		resetStagedTxFees()
+	}()
+
+	var (
+		owner    = types.StringToAddress(testOwnerAddress)
+		producer = types.StringToAddress("0x2")
+		txHash   = types.StringToHash("0x1")
+	)
+
+	InitializeSupplyTracker(big.NewInt(1000))
+	SetFeeConfig(FeeConfig{PauseOwnerPayout: true})
+	resetStagedTxFees()
+
+	txn := newMockTxn()
+
+	if err := DistributeTxFeesToValidator(txn, big.NewInt(100), owner, producer, 1, testBlockHash(1), txHash); err != nil {
+		t.Fatalf("Failed to distribute fees: %v", err)
+	}
+
+	if err := CommitBlockFeeEffects(1, testBlockHash(1)); err != nil {
+		t.Fatalf("Failed to commit fee effects: %v", err)
+	}
+
+	if txn.GetBalance(owner).Sign() != 0 {
+		t.Errorf("Expected paused owner to receive nothing, got %s", txn.GetBalance(owner).String())
+	}
+
+	if txn.GetBalance(producer).Cmp(big.NewInt(50)) != 0 {
+		t.Errorf("Expected producer to receive 50, got %s", txn.GetBalance(producer).String())
+	}
+
+	// Pausing both recipients burns all fees
+	SetFeeConfig(FeeConfig{PauseOwnerPayout: true, PauseProducerPayout: true})
+
+	if err := DistributeTxFeesToValidator(txn, big.NewInt(100), owner, producer, 2, testBlockHash(2), txHash); err != nil {
+		t.Fatalf("Failed to distribute fees: %v", err)
+	}
+
+	if err := CommitBlockFeeEffects(2, testBlockHash(2)); err != nil {
+		t.Fatalf("Failed to commit fee effects: %v", err)
+	}
+
+	if txn.GetBalance(producer).Cmp(big.NewInt(50)) != 0 {
+		t.Errorf("Expected paused producer balance to stay 50, got %s", txn.GetBalance(producer).String())
+	}
+
+	log := GetSupplyAuditLog()
+	if len(log) != 2 || log[0].Reason != ReasonPausedPayout || log[1].Amount.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("Expected paused shares to be burned, got %+v", log)
+	}
+
+	if got := GetCurrentSupply(); got.Cmp(big.NewInt(850)) != 0 {
+		t.Errorf("Expected supply 850, got %s", got.String())
+	}
+}
+
+func TestDistributeTxFeesToValidatorOwnerFeeFloor(t *testing.T) {
+	defer SetFeeConfig(FeeConfig{})
+
+	var (
+		owner    = types.StringToAddress(testOwnerAddress)
+		producer = types.StringToAddress("0x2")
+	)
+
+	SetFeeConfig(FeeConfig{OwnerFeeFloor: big.NewInt(10)})
+	defer resetStagedTxFees()
+
+	cases := []struct {
+		name        string
+		totalFees   int64
+		ownerFee    int64
+		producerFee int64
+	}{
+		{"half above floor", 40, 20, 20},
+		{"half exactly at floor", 20, 10, 10},
+		{"half one wei below floor", 19, 10, 9},
+		{"total one wei above floor", 11, 10, 1},
+		{"total at floor", 10, 10, 0},
+		{"total below floor", 3, 3, 0},
+	}
+
+	for _, c := range cases {
+		txn := newMockTxn()
+
+		if err := DistributeTxFeesToValidator(txn, big.NewInt(c.totalFees), owner, producer, 1, testBlockHash(1), types.ZeroHash); err != nil {
+			t.Fatalf("%s: failed to distribute fees: %v", c.name, err)
+		}
+
+		if txn.GetBalance(owner).Int64() != c.ownerFee || txn.GetBalance(producer).Int64() != c.producerFee {
+			t.Errorf("%s: expected %d/%d, got %s/%s", c.name, c.ownerFee, c.producerFee,
+				txn.GetBalance(owner).String(), txn.GetBalance(producer).String())
+		}
+	}
+}
+
+func TestGetGlobalSupplyTrackerConcurrentInit(t *testing.T) {
+	defer InitializeSupplyTracker(big.NewInt(0))
+
+	const workers = 32
+
+	globalTrackerLock.Lock()
+	globalSupplyTracker = nil
+	globalTrackerLock.Unlock()
+
+	var wg sync.WaitGroup
+
+	trackers := make([]*SystemSupplyTracker, workers)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			trackers[i] = GetGlobalSupplyTracker()
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, tracker := range trackers {
+		if tracker != trackers[0] {
+			t.Fatalf("Expected a single tracker instance, goroutine %d got a different one", i)
+		}
+	}
+
+	// An explicit initialization is not clobbered by later lazy lookups
+	InitializeSupplyTracker(big.NewInt(42))
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_ = GetGlobalSupplyTracker()
+		}()
+	}
+
+	wg.Wait()
+
+	if got := GetCurrentSupply(); got.Cmp(big.NewInt(42)) != 0 {
+		t.Errorf("Expected explicitly initialized supply 42, got %s", got.String())
+	}
+}
+
+func TestGetSupplyDelta(t *testing.T) {
+	defer InitializeSupplyTracker(big.NewInt(0))
+
+	InitializeSupplyTracker(big.NewInt(0))
+
+	reward := big.NewInt(BlockRewardAmount)
+
+	// Deterministic formula without burns
+	if got := GetSupplyDelta(10, 15); got.Cmp(new(big.Int).Mul(big.NewInt(5), reward)) != 0 {
+		t.Errorf("Expected delta of 5 AZE, got %s", got.String())
+	}
+
+	if got := GetSupplyDelta(15, 10); got.Cmp(new(big.Int).Mul(big.NewInt(-5), reward)) != 0 {
+		t.Errorf("Expected delta of -5 AZE for a reversed range, got %s", got.String())
+	}
+
+	if got := GetSupplyDelta(7, 7); got.Sign() != 0 {
+		t.Errorf("Expected zero delta for an empty range, got %s", got.String())
+	}
+
+	// With burns the audit log is used
+	tracker := GetGlobalSupplyTracker().tracker
+
+	if err := tracker.Mint(big.NewInt(100), 2, "consensus_engine"); err != nil {
+		t.Fatalf("Failed to mint: %v", err)
+	}
+
+	if err := tracker.Burn(big.NewInt(30), 3, "consensus_engine"); err != nil {
+		t.Fatalf("Failed to burn: %v", err)
+	}
+
+	if err := tracker.Burn(big.NewInt(50), 4, "consensus_engine"); err != nil {
+		t.Fatalf("Failed to burn: %v", err)
+	}
+
+	if got := GetSupplyDelta(2, 4); got.Cmp(big.NewInt(-80)) != 0 {
+		t.Errorf("Expected net burn of -80, got %s", got.String())
+	}
+
+	if got := GetSupplyDelta(4, 1); got.Cmp(big.NewInt(-20)) != 0 {
+		t.Errorf("Expected -20 for the reversed range, got %s", got.String())
+	}
+
+	// Blocks outside the span of the log fall back to the formula
+	expected := new(big.Int).Mul(big.NewInt(3), reward)
+	expected.Add(expected, big.NewInt(20))
+
+	if got := GetSupplyDelta(0, 6); got.Cmp(expected) != 0 {
+		t.Errorf("Expected %s for a range around the log, got %s", expected.String(), got.String())
+	}
+}
+
+func TestBurnFromAccount(t *testing.T) {
+	defer func() {
+		SetBurnToDeadAddress(false)
+		InitializeSupplyTracker(big.NewInt(0))
+	}()
+
+	InitializeSupplyTracker(big.NewInt(1000))
+
+	account := types.StringToAddress("0x1")
+	txn := newMockTxn()
+	txn.AddBalance(account, big.NewInt(100))
+
+	if err := BurnFromAccount(txn, account, big.NewInt(30), 1); err != nil {
+		t.Fatalf("Failed to burn: %v", err)
+	}
+
+	if got := txn.GetBalance(account); got.Cmp(big.NewInt(70)) != 0 {
+		t.Errorf("Expected account balance 70, got %s", got.String())
+	}
+
+	if err := BurnFromAccount(txn, account, big.NewInt(71), 2); !errors.Is(err, ErrInsufficientBalance) {
+		t.Errorf("Expected ErrInsufficientBalance, got %v", err)
+	}
+
+	SetBurnToDeadAddress(true)
+
+	if err := BurnFromAccount(txn, account, big.NewInt(20), 3); err != nil {
+		t.Fatalf("Failed to burn: %v", err)
+	}
+
+	if got := txn.GetBalance(BurnAddress); got.Cmp(big.NewInt(20)) != 0 {
+		t.Errorf("Expected burn address balance 20, got %s", got.String())
+	}
+
+	log := GetSupplyAuditLog()
+	if len(log) != 2 || log[0].Reason != ReasonAccountBurn || log[1].Amount.Cmp(big.NewInt(20)) != 0 {
+		t.Errorf("Expected two account burn entries, got %+v", log)
+	}
+
+	if got := GetCurrentSupply(); got.Cmp(big.NewInt(950)) != 0 {
+		t.Errorf("Expected supply 950, got %s", got.String())
+	}
+}
+
+// mockAccounts reports the addresses it holds as existing accounts
+type mockAccounts map[types.Address]bool
+
+func (m mockAccounts) AccountExists(addr types.Address) bool {
+	return m[addr]
+}
+
+func TestCheckStakingContractDeployed(t *testing.T) {
+	defaultAddr := StakingContractAddress
+	defer SetStakingContractAddress(defaultAddr)
+
+	custom := types.StringToAddress("0x2002")
+	accounts := mockAccounts{custom: true}
+
+	if CheckStakingContractDeployed(accounts) {
+		t.Fatal("Expected no contract at the default address")
+	}
+
+	SetStakingContractAddress(custom)
+
+	if !CheckStakingContractDeployed(accounts) {
+		t.Error("Expected contract to be found at the configured address")
+	}
+}
+
+// mockExecutionResult is a canned contract call outcome
+type mockExecutionResult struct {
+	err error
+}
+
+func (r *mockExecutionResult) Failed() bool  { return r.err != nil }
+func (r *mockExecutionResult) GetErr() error { return r.err }
+
+// mockTransition records balances and answers every contract call with callErr,
+// transferring the call value only when the call succeeds. SubBalance fails with
+// subErr when set.
+type mockTransition struct {
+	*mockTxn
+	callErr error
+	subErr  error
+	calls   [][]byte
+	// onCall runs when the contract is called, like contract code would
+	onCall func()
+}
+
+func (m *mockTransition) SubBalance(addr types.Address, amount *big.Int) error {
+	if m.subErr != nil {
+		return m.subErr
+	}
+
+	return m.mockTxn.SubBalance(addr, amount)
+}
+
+func (m *mockTransition) Call2(from, to types.Address, input []byte, value *big.Int, _ uint64) ExecutionResult {
+	m.calls = append(m.calls, input)
+
+	if m.onCall != nil {
+		m.onCall()
+	}
+
+	if m.callErr == nil && value != nil {
+		_ = m.SubBalance(from, value)
+		m.AddBalance(to, value)
+	}
+
+	return &mockExecutionResult{err: m.callErr}
+}
+
+func (m *mockTransition) AccountExists(addr types.Address) bool {
+	_, ok := m.balances[addr]
+
+	return ok
+}
+
+func TestMintAndNotify(t *testing.T) {
+	InitializeSupplyTracker(big.NewInt(0))
+	defer InitializeSupplyTracker(big.NewInt(0))
+
+	contract := types.StringToAddress("0x1001")
+	selector := []byte{0xd0, 0xe3, 0x0d, 0xb0}
+
+	transition := &mockTransition{mockTxn: newMockTxn()}
+
+	// The contract runs outside the tracker lock and sees the reserved reward
+	var supplyDuringCall *big.Int
+
+	transition.onCall = func() {
+		supplyDuringCall = GetCurrentSupply()
+	}
+
+	result, err := MintAndNotify(transition, 1, contract, selector)
+	if err != nil || result == nil || result.Failed() {
+		t.Fatalf("Expected successful mint and notify, got %v", err)
+	}
+
+	if supplyDuringCall == nil || supplyDuringCall.Cmp(big.NewInt(BlockRewardAmount)) != 0 {
+		t.Errorf("Expected the reserved reward in the supply during the call, got %v", supplyDuringCall)
+	}
+
+	transition.onCall = nil
+
+	if len(transition.calls) != 1 || !bytes.Equal(transition.calls[0], selector) {
+		t.Errorf("Expected the contract to be called with the selector, got %v", transition.calls)
+	}
+
+	if transition.GetBalance(contract).Cmp(big.NewInt(BlockRewardAmount)) != 0 {
+		t.Errorf("Expected contract credited with the reward, got %s", transition.GetBalance(contract).String())
+	}
+
+	if GetCurrentSupply().Cmp(big.NewInt(BlockRewardAmount)) != 0 {
+		t.Errorf("Expected reward recorded, got supply %s", GetCurrentSupply().String())
+	}
+
+	// A failed call reverts the credit and records nothing
+	transition.callErr = errors.New("execution reverted")
+
+	result, err = MintAndNotify(transition, 2, contract, selector)
+	if !errors.Is(err, ErrMintNotifyFailed) || result == nil || !result.Failed() {
+		t.Fatalf("Expected ErrMintNotifyFailed with the failed result, got %v", err)
+	}
+
+	if transition.GetBalance(contract).Cmp(big.NewInt(BlockRewardAmount)) != 0 {
+		t.Errorf("Expected the failed credit to be reverted, got %s", transition.GetBalance(contract).String())
+	}
+
+	if len(GetSupplyAuditLog()) != 1 {
+		t.Errorf("Expected no audit entry for the failed call, got %d entries", len(GetSupplyAuditLog()))
+	}
+
+	// A credit that cannot be reverted is still recorded
+	transition.subErr = errors.New("insufficient balance")
+
+	if _, err = MintAndNotify(transition, 3, contract, selector); !errors.Is(err, ErrMintNotifyFailed) {
+		t.Fatalf("Expected ErrMintNotifyFailed, got %v", err)
+	}
+
+	if transition.GetBalance(contract).Cmp(big.NewInt(2*BlockRewardAmount)) != 0 {
+		t.Errorf("Expected the credit to stay, got %s", transition.GetBalance(contract).String())
+	}
+
+	if GetCurrentSupply().Cmp(transition.GetBalance(contract)) != 0 {
+		t.Errorf("Expected supply to match the credited balance, got %s", GetCurrentSupply().String())
+	}
+
+	// The consensus quota applies like for every other reward mint
+	if err := GetGlobalSupplyTracker().tracker.SetMintQuota(consensusEngineCaller, GetCurrentSupply()); err != nil {
+		t.Fatalf("Failed to set quota: %v", err)
+	}
+
+	calls := len(transition.calls)
+
+	if _, err = MintAndNotify(transition, 4, contract, selector); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Expected ErrQuotaExceeded, got %v", err)
+	}
+
+	if len(transition.calls) != calls {
+		t.Error("Expected the contract not to be called above the quota")
+	}
+}
+
+func TestMintAndNotifyCapReachedWhenCreditStays(t *testing.T) {
+	defaultMax := getMaxSupply()
+
+	defer func() {
+		OnCapReached(nil)
+		InitializeSupplyTracker(big.NewInt(0))
+
+		if err := SetCapReachedFlagPath(""); err != nil {
+			t.Fatalf("Failed to reset cap reached flag: %v", err)
+		}
+
+		if err := SetMaxSupply(defaultMax); err != nil {
+			t.Fatalf("Failed to restore max supply: %v", err)
+		}
+	}()
+
+	if err := SetCapReachedFlagPath(""); err != nil {
+		t.Fatalf("Failed to reset cap reached flag: %v", err)
+	}
+
+	if err := SetMaxSupply(big.NewInt(BlockRewardAmount)); err != nil {
+		t.Fatalf("Failed to set max supply: %v", err)
+	}
+
+	InitializeSupplyTracker(big.NewInt(0))
+
+	var fired []uint64
+
+	OnCapReached(func(blockNumber uint64) {
+		fired = append(fired, blockNumber)
+	})
+
+	transition := &mockTransition{
+		mockTxn: newMockTxn(),
+		callErr: errors.New("execution reverted"),
+		subErr:  errors.New("insufficient balance"),
+	}
+
+	if _, err := MintAndNotify(transition, 1, types.StringToAddress("0x1001"), []byte{0x01}); !errors.Is(err, ErrMintNotifyFailed) {
+		t.Fatalf("Expected ErrMintNotifyFailed, got %v", err)
+	}
+
+	if len(fired) != 1 || fired[0] != 1 {
+		t.Errorf("Expected the cap hook to fire for the kept mint, got %v", fired)
+	}
+}
+
+func TestDistributeTxFeesToTreasury(t *testing.T) {
+	defer func() {
+		globalFeeLedger = NewFeeLedger()
+		resetStagedTxFees()
+	}()
+
+	globalFeeLedger = NewFeeLedger()
+	resetStagedTxFees()
+
+	var (
+		treasury = types.StringToAddress("0x3003")
+		selector = []byte{0xd0, 0xe3, 0x0d, 0xb0}
+		txA      = types.StringToHash("0xa")
+		txB      = types.StringToHash("0xb")
+		txC      = types.StringToHash("0xc")
+	)
+
+	transition := &mockTransition{mockTxn: newMockTxn()}
+
+	result, err := DistributeTxFeesToTreasury(transition, big.NewInt(0), treasury, selector, 1, testBlockHash(1), txA)
+	if result != nil || err != nil {
+		t.Errorf("Expected no call without fees, got %v, %v", result, err)
+	}
+
+	result, err = DistributeTxFeesToTreasury(transition, big.NewInt(100), treasury, selector, 1, testBlockHash(1), txA)
+	if err != nil || result == nil || result.Failed() {
+		t.Fatalf("Expected a successful deposit, got %v, %v", result, err)
+	}
+
+	if len(transition.calls) != 1 || !bytes.Equal(transition.calls[0], selector) {
+		t.Errorf("Expected the treasury to be called with the selector, got %v", transition.calls)
+	}
+
+	// A failed deposit credits the treasury directly
+	transition.callErr = errors.New("execution reverted")
+
+	result, err = DistributeTxFeesToTreasury(transition, big.NewInt(50), treasury, selector, 1, testBlockHash(1), txB)
+	if err != nil || result == nil || !result.Failed() {
+		t.Fatalf("Expected the failed result to be returned, got %v, %v", result, err)
+	}
+
+	if got := transition.GetBalance(contracts.SystemCaller); got.Sign() != 0 {
+		t.Errorf("Expected the system caller to keep nothing, got %s", got.String())
+	}
+
+	// When the funding cannot be removed the treasury is not credited either
+	transition.subErr = errors.New("insufficient balance")
+
+	if _, err = DistributeTxFeesToTreasury(transition, big.NewInt(25), treasury, selector, 1, testBlockHash(1), txC); err == nil {
+		t.Fatal("Expected the funding revert error")
+	}
+
+	if got := transition.GetBalance(treasury); got.Cmp(big.NewInt(150)) != 0 {
+		t.Errorf("Expected treasury balance 150, got %s", got.String())
+	}
+
+	if err := CommitBlockFeeEffects(1, testBlockHash(1)); err != nil {
+		t.Fatalf("Failed to commit fee effects: %v", err)
+	}
+
+	if got := GetFeesEarned(treasury); got.Cmp(big.NewInt(150)) != 0 {
+		t.Errorf("Expected 150 in treasury fees recorded, got %s", got.String())
+	}
+
+	if got := GetTotalFeesToTreasury(); got.Cmp(big.NewInt(150)) != 0 {
+		t.Errorf("Expected 150 paid to the treasury, got %s", got.String())
+	}
+
+	// Treasury payouts count towards the total fees distributed
+	if got := GetTotalFeesDistributed(); got.Cmp(big.NewInt(150)) != 0 {
+		t.Errorf("Expected 150 distributed in total, got %s", got.String())
+	}
+}
+
+func TestInitializeFromGenesisWithBurns(t *testing.T) {
+	defer func() {
+		SetGenesisAllocCache(nil)
+		_ = SetGenesisBurns(nil)
+		InitializeSupplyTracker(big.NewInt(0))
+	}()
+
+	if err := SetGenesisBurns([]GenesisBurn{{Amount: big.NewInt(0), Reason: "empty"}}); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("Expected ErrInvalidAmount for a zero burn, got %v", err)
+	}
+
+	SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{
+		types.StringToAddress("0x1"): {Balance: big.NewInt(1000)},
+	})
+
+	burns := []GenesisBurn{
+		{Amount: big.NewInt(100), Reason: "team vesting"},
+		{Amount: big.NewInt(50), Reason: "ecosystem lock"},
+	}
+
+	if err := SetGenesisBurns(burns); err != nil {
+		t.Fatalf("Failed to set genesis burns: %v", err)
+	}
+
+	if err := InitializeFromGenesis(); err != nil {
+		t.Fatalf("Failed to initialize from genesis: %v", err)
+	}
+
+	if got := GetCurrentSupply(); got.Cmp(big.NewInt(850)) != 0 {
+		t.Errorf("Expected supply 850 after genesis burns, got %s", got.String())
+	}
+
+	if got := getCurrentSupplyFromBlockNumber(0); got.Cmp(big.NewInt(850)) != 0 {
+		t.Errorf("Expected formula supply 850 at block 0, got %s", got.String())
+	}
+
+	log := GetGlobalSupplyTracker().GetAuditLog()
+	if len(log) != len(burns) {
+		t.Fatalf("Expected %d audit entries, got %d", len(burns), len(log))
+	}
+
+	for i, entry := range log {
+		if entry.Type != ChangeBurn || entry.BlockNumber != 0 || entry.Caller != GenesisBurnCaller {
+			t.Errorf("Unexpected genesis burn entry %d: %+v", i, entry)
+		}
+
+		if entry.Reason != burns[i].Reason || entry.Amount.Cmp(burns[i].Amount) != 0 {
+			t.Errorf("Expected entry %d to record %s (%s), got %s (%s)",
+				i, burns[i].Amount.String(), burns[i].Reason, entry.Amount.String(), entry.Reason)
+		}
+	}
+
+	if got := GetGenesisBurns(); len(got) != 2 || got[1].Reason != "ecosystem lock" {
+		t.Errorf("Expected the configured burns to be queryable, got %+v", got)
+	}
+
+	// Burns exceeding the genesis total are rejected
+	if err := SetGenesisBurns([]GenesisBurn{{Amount: big.NewInt(1001)}}); err != nil {
+		t.Fatalf("Failed to set genesis burns: %v", err)
+	}
+
+	if err := InitializeFromGenesis(); !errors.Is(err, ErrInsufficientSupply) {
+		t.Errorf("Expected ErrInsufficientSupply, got %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var ErrNoValidators = errors.New("no validators to distribute to")
+
+// EpochRewardAccumulator collects per-block rewards and mints them once per epoch,
+// recording a single audit entry for the whole epoch instead of one per block.
+// Between finalizations it holds the undistributed reward, which is not part of
+// the tracked supply yet.
+type EpochRewardAccumulator struct {
+	tracker *SystemSupplyTracker
+	pending *big.Int
+	// blocks is the number of blocks accumulated since the last finalization
+	blocks    uint64
+	lastBlock uint64
+	mutex     sync.Mutex
+}
+
+// NewEpochRewardAccumulator creates an accumulator minting through the given tracker
+func NewEpochRewardAccumulator(tracker *SystemSupplyTracker) *EpochRewardAccumulator {
+	return &EpochRewardAccumulator{
+		tracker: tracker,
+		pending: big.NewInt(0),
+	}
+}
+
+// Accumulate adds the reward of a block to the pending epoch total.
+// Blocks must be accumulated in increasing order.
+func (a *EpochRewardAccumulator) Accumulate(blockNumber uint64, reward *big.Int) error {
+	if reward == nil || reward.Sign() <= 0 {
+		return ErrInvalidAmount
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.blocks > 0 && blockNumber <= a.lastBlock {
+		return fmt.Errorf("%w: block %d, last accumulated block %d", ErrNonMonotonicBlock, blockNumber, a.lastBlock)
+	}
+
+	a.pending.Add(a.pending, reward)
+	a.blocks++
+	a.lastBlock = blockNumber
+
+	return nil
+}
+
+// Pending returns the reward accumulated since the last finalization
+func (a *EpochRewardAccumulator) Pending() *big.Int {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return new(big.Int).Set(a.pending)
+}
+
+// FinalizeEpoch mints the pending reward and splits it equally among the validators,
+// with the rounding dust going to the first one. See FinalizeEpochByStake.
+func (a *EpochRewardAccumulator) FinalizeEpoch(
+	txn interface{ AddBalance(types.Address, *big.Int) },
+	epochNumber uint64,
+	validators []types.Address,
+) (MintResult, error) {
+	staked := make([]StakedValidator, len(validators))
+	for i, validator := range validators {
+		staked[i] = StakedValidator{Address: validator, Stake: big.NewInt(1)}
+	}
+
+	return a.FinalizeEpochByStake(txn, epochNumber, staked)
+}
+
+// FinalizeEpochByStake mints the pending reward, clamped to the supply cap, and
+// splits it among the validators proportionally to their stake. A single audit
+// entry for the epoch total is recorded at the last accumulated block, and the
+// per-validator shares are only credited to state. Whatever the cap
+// does not allow to be minted is dropped, and the pending total is reset. On error
+// nothing is minted and the pending reward is kept for a later attempt.
+func (a *EpochRewardAccumulator) FinalizeEpochByStake(
+	txn interface{ AddBalance(types.Address, *big.Int) },
+	epochNumber uint64,
+	validators []StakedValidator,
+) (MintResult, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	result := MintResult{Minted: big.NewInt(0)}
+
+	if a.blocks == 0 {
+		return result, nil
+	}
+
+	if len(validators) == 0 {
+		return result, fmt.Errorf("%w: epoch %d", ErrNoValidators, epochNumber)
+	}
+
+	totalStake := big.NewInt(0)
+
+	for _, validator := range validators {
+		if validator.Stake == nil || validator.Stake.Sign() < 0 {
+			return result, fmt.Errorf("%w: stake of validator %s", ErrInvalidAmount, validator.Address)
+		}
+
+		totalStake.Add(totalStake, validator.Stake)
+	}
+
+	if totalStake.Sign() == 0 {
+		return result, fmt.Errorf("%w: epoch %d has no stake", ErrNoValidators, epochNumber)
+	}
+
+	if !isMintingEnabled() {
+		return result, newSupplyError(ErrMintingPaused, a.lastBlock, a.pending)
+	}
+
+	result, err := a.tracker.mintRewards(a.lastBlock, func() (MintResult, error) {
+		return a.tracker.mintRewardsLocked(txn, rewardMint{
+			firstBlock: a.lastBlock,
+			block:      a.lastBlock,
+			blocks:     a.blocks,
+			reward:     new(big.Int).Set(a.pending),
+			reason:     fmt.Sprintf("%s:%d", ReasonEpochReward, epochNumber),
+			split: func(minted *big.Int) []rewardPayout {
+				return stakePayouts(minted, validators, totalStake)
+			},
+			singleEntry: true,
+		})
+	})
+	if err != nil {
+		return MintResult{Minted: big.NewInt(0)}, err
+	}
+
+	if result.Minted.Sign() > 0 {
+		getSupplyLogger().Debug("epoch reward split among validators", "epoch", epochNumber,
+			"reward", FormatAZE(result.Minted), "validators", len(validators))
+	} else {
+		getSupplyLogger().Debug("supply cap reached, no epoch reward minted", "epoch", epochNumber)
+	}
+
+	a.pending = big.NewInt(0)
+	a.blocks = 0
+
+	return result, nil
+}
@@ -0,0 +1,91 @@
+package staking
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestEpochRewardAccumulator(t *testing.T) {
+	sst := NewSystemSupplyTracker(big.NewInt(0))
+	accumulator := NewEpochRewardAccumulator(sst)
+	txn := newMockTxn()
+
+	validatorA := types.StringToAddress("0x1")
+	validatorB := types.StringToAddress("0x2")
+
+	for block := uint64(1); block <= 3; block++ {
+		if err := accumulator.Accumulate(block, big.NewInt(101)); err != nil {
+			t.Fatalf("Failed to accumulate block %d: %v", block, err)
+		}
+	}
+
+	if err := accumulator.Accumulate(3, big.NewInt(101)); !errors.Is(err, ErrNonMonotonicBlock) {
+		t.Fatalf("Expected ErrNonMonotonicBlock, got %v", err)
+	}
+
+	if accumulator.Pending().Cmp(big.NewInt(303)) != 0 || sst.GetCurrentSupply().Sign() != 0 {
+		t.Fatalf("Expected 303 pending and nothing minted, got %s", accumulator.Pending().String())
+	}
+
+	if _, err := accumulator.FinalizeEpoch(txn, 1, nil); !errors.Is(err, ErrNoValidators) {
+		t.Fatalf("Expected ErrNoValidators, got %v", err)
+	}
+
+	result, err := accumulator.FinalizeEpoch(txn, 1, []types.Address{validatorA, validatorB})
+	if err != nil || result.Minted.Cmp(big.NewInt(303)) != 0 {
+		t.Fatalf("Expected 303 minted, got %+v, %v", result, err)
+	}
+
+	// The odd wei goes to the first validator
+	if txn.GetBalance(validatorA).Cmp(big.NewInt(152)) != 0 || txn.GetBalance(validatorB).Cmp(big.NewInt(151)) != 0 {
+		t.Errorf("Unexpected split: %s / %s", txn.GetBalance(validatorA).String(), txn.GetBalance(validatorB).String())
+	}
+
+	auditLog := sst.GetAuditLog()
+	if len(auditLog) != 1 {
+		t.Fatalf("Expected a single epoch entry, got %+v", auditLog)
+	}
+
+	if entry := auditLog[0]; entry.BlockNumber != 3 || entry.Reason != "epoch_reward:1" ||
+		entry.Amount.Cmp(big.NewInt(303)) != 0 || entry.Recipient != nil {
+		t.Errorf("Unexpected epoch entry: %+v", entry)
+	}
+
+	if accumulator.Pending().Sign() != 0 {
+		t.Errorf("Expected pending reward reset, got %s", accumulator.Pending().String())
+	}
+}
+
+func TestEpochRewardAccumulatorNearCap(t *testing.T) {
+	sst := NewSystemSupplyTracker(new(big.Int).Sub(getMaxSupply(), big.NewInt(100)))
+	accumulator := NewEpochRewardAccumulator(sst)
+	txn := newMockTxn()
+
+	validators := []StakedValidator{
+		{Address: types.StringToAddress("0x1"), Stake: big.NewInt(1)},
+		{Address: types.StringToAddress("0x2"), Stake: big.NewInt(3)},
+	}
+
+	for block := uint64(1); block <= 2; block++ {
+		if err := accumulator.Accumulate(block, big.NewInt(BlockRewardAmount)); err != nil {
+			t.Fatalf("Failed to accumulate block %d: %v", block, err)
+		}
+	}
+
+	result, err := accumulator.FinalizeEpochByStake(txn, 7, validators)
+	if err != nil || !result.Partial || !result.CapReached || result.Minted.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("Expected a partial epoch of 100 wei, got %+v, %v", result, err)
+	}
+
+	if txn.GetBalance(validators[0].Address).Cmp(big.NewInt(25)) != 0 ||
+		txn.GetBalance(validators[1].Address).Cmp(big.NewInt(75)) != 0 {
+		t.Errorf("Expected a 25/75 stake split")
+	}
+
+	if sst.GetCurrentSupply().Cmp(getMaxSupply()) != 0 {
+		t.Errorf("Expected supply at the cap, got %s", sst.GetCurrentSupply().String())
+	}
+}
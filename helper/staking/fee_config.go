@@ -0,0 +1,51 @@
+package staking
+
+import (
+	"math/big"
+	"sync"
+)
+
+var (
+	// feeConfig is the active fee distribution configuration
+	feeConfig     FeeConfig
+	feeConfigLock sync.RWMutex
+)
+
+// FeeConfig controls how DistributeTxFeesToValidator pays out transaction fees.
+// A paused recipient's share is burned instead of being credited, so pausing
+// both the owner and the producer burns all fees.
+type FeeConfig struct {
+	// PauseOwnerPayout burns the owner's share instead of paying it
+	PauseOwnerPayout bool
+	// PauseProducerPayout burns the block producer's share instead of paying it
+	PauseProducerPayout bool
+	// BurnZeroAddressFees burns a share addressed to the zero address instead of
+	// rejecting the distribution with ErrZeroAddressRecipient
+	BurnZeroAddressFees bool
+	// OwnerFeeFloor is the minimum owner share in wei, nil or zero for none. When the
+	// 50/50 split leaves the owner less than the floor, the owner receives the floor
+	// and the producer the remainder. When the total fees do not exceed the floor,
+	// the owner receives all of them. Fees split by FlushBlockFees get the floor once
+	// per block rather than once per transaction.
+	OwnerFeeFloor *big.Int
+}
+
+// SetFeeConfig replaces the fee distribution configuration
+func SetFeeConfig(config FeeConfig) {
+	feeConfigLock.Lock()
+	defer feeConfigLock.Unlock()
+
+	if config.OwnerFeeFloor != nil {
+		config.OwnerFeeFloor = new(big.Int).Set(config.OwnerFeeFloor)
+	}
+
+	feeConfig = config
+}
+
+// GetFeeConfig returns the fee distribution configuration
+func GetFeeConfig() FeeConfig {
+	feeConfigLock.RLock()
+	defer feeConfigLock.RUnlock()
+
+	return feeConfig
+}
@@ -0,0 +1,187 @@
+package staking
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Global ledger of transaction fees paid out by DistributeTxFeesToValidator and
+// DistributeTxFeesToTreasury, fed by CommitBlockFeeEffects once per inserted block
+var globalFeeLedger = NewFeeLedger()
+
+// FeeLedger accumulates the lifetime transaction fees earned per address,
+// along with the totals paid to owners, to block producers and to the treasury
+type FeeLedger struct {
+	earned        map[types.Address]*big.Int
+	ownerTotal    *big.Int
+	producerTotal *big.Int
+	treasuryTotal *big.Int
+	mutex         sync.RWMutex
+}
+
+// NewFeeLedger creates an empty fee ledger
+func NewFeeLedger() *FeeLedger {
+	return &FeeLedger{
+		earned:        make(map[types.Address]*big.Int),
+		ownerTotal:    big.NewInt(0),
+		producerTotal: big.NewInt(0),
+		treasuryTotal: big.NewInt(0),
+	}
+}
+
+// Record adds a fee payment to the address' total
+func (fl *FeeLedger) Record(addr types.Address, amount *big.Int) {
+	if amount == nil || amount.Sign() <= 0 {
+		return
+	}
+
+	fl.mutex.Lock()
+	defer fl.mutex.Unlock()
+
+	fl.recordLocked(addr, amount)
+}
+
+// RecordOwnerFee adds an owner fee payment to the address' total and to the owner total
+func (fl *FeeLedger) RecordOwnerFee(addr types.Address, amount *big.Int) {
+	if amount == nil || amount.Sign() <= 0 {
+		return
+	}
+
+	fl.mutex.Lock()
+	defer fl.mutex.Unlock()
+
+	fl.recordLocked(addr, amount)
+	fl.ownerTotal.Add(fl.ownerTotal, amount)
+}
+
+// RecordProducerFee adds a block producer fee payment to the address' total and
+// to the producer total
+func (fl *FeeLedger) RecordProducerFee(addr types.Address, amount *big.Int) {
+	if amount == nil || amount.Sign() <= 0 {
+		return
+	}
+
+	fl.mutex.Lock()
+	defer fl.mutex.Unlock()
+
+	fl.recordLocked(addr, amount)
+	fl.producerTotal.Add(fl.producerTotal, amount)
+}
+
+// RecordTreasuryFee adds a treasury fee payment to the address' total and to the
+// treasury total
+func (fl *FeeLedger) RecordTreasuryFee(addr types.Address, amount *big.Int) {
+	if amount == nil || amount.Sign() <= 0 {
+		return
+	}
+
+	fl.mutex.Lock()
+	defer fl.mutex.Unlock()
+
+	fl.recordLocked(addr, amount)
+	fl.treasuryTotal.Add(fl.treasuryTotal, amount)
+}
+
+// recordLocked adds amount to the address' total. Caller must hold the lock.
+func (fl *FeeLedger) recordLocked(addr types.Address, amount *big.Int) {
+	total, ok := fl.earned[addr]
+	if !ok {
+		total = big.NewInt(0)
+		fl.earned[addr] = total
+	}
+
+	total.Add(total, amount)
+}
+
+// FeesEarned returns the total fees earned by the address
+func (fl *FeeLedger) FeesEarned(addr types.Address) *big.Int {
+	fl.mutex.RLock()
+	defer fl.mutex.RUnlock()
+
+	if total, ok := fl.earned[addr]; ok {
+		return new(big.Int).Set(total)
+	}
+
+	return big.NewInt(0)
+}
+
+// TotalToOwner returns the lifetime fees paid to owners
+func (fl *FeeLedger) TotalToOwner() *big.Int {
+	fl.mutex.RLock()
+	defer fl.mutex.RUnlock()
+
+	return new(big.Int).Set(fl.ownerTotal)
+}
+
+// TotalToProducers returns the lifetime fees paid to block producers
+func (fl *FeeLedger) TotalToProducers() *big.Int {
+	fl.mutex.RLock()
+	defer fl.mutex.RUnlock()
+
+	return new(big.Int).Set(fl.producerTotal)
+}
+
+// TotalToTreasury returns the lifetime fees paid to the treasury
+func (fl *FeeLedger) TotalToTreasury() *big.Int {
+	fl.mutex.RLock()
+	defer fl.mutex.RUnlock()
+
+	return new(big.Int).Set(fl.treasuryTotal)
+}
+
+// TotalDistributed returns the lifetime fees paid to owners, block producers and the treasury
+func (fl *FeeLedger) TotalDistributed() *big.Int {
+	fl.mutex.RLock()
+	defer fl.mutex.RUnlock()
+
+	total := new(big.Int).Add(fl.ownerTotal, fl.producerTotal)
+
+	return total.Add(total, fl.treasuryTotal)
+}
+
+// AllEarners returns a copy of the total fees earned by every address
+func (fl *FeeLedger) AllEarners() map[types.Address]*big.Int {
+	fl.mutex.RLock()
+	defer fl.mutex.RUnlock()
+
+	earners := make(map[types.Address]*big.Int, len(fl.earned))
+	for addr, total := range fl.earned {
+		earners[addr] = new(big.Int).Set(total)
+	}
+
+	return earners
+}
+
+// GetFeesEarned returns the lifetime fees paid to the address by fee distribution
+func GetFeesEarned(addr types.Address) *big.Int {
+	return globalFeeLedger.FeesEarned(addr)
+}
+
+// GetAllFeeEarners returns the lifetime fees paid to every address by fee distribution
+func GetAllFeeEarners() map[types.Address]*big.Int {
+	return globalFeeLedger.AllEarners()
+}
+
+// GetTotalFeesDistributed returns the lifetime fees paid out by fee distribution,
+// to owners, block producers and the treasury. Shares burned instead of paid, such
+// as zero address or paused payouts, are not included.
+func GetTotalFeesDistributed() *big.Int {
+	return globalFeeLedger.TotalDistributed()
+}
+
+// GetTotalFeesToOwner returns the lifetime fees paid to the owner by fee distribution
+func GetTotalFeesToOwner() *big.Int {
+	return globalFeeLedger.TotalToOwner()
+}
+
+// GetTotalFeesToProducers returns the lifetime fees paid to block producers by fee distribution
+func GetTotalFeesToProducers() *big.Int {
+	return globalFeeLedger.TotalToProducers()
+}
+
+// GetTotalFeesToTreasury returns the lifetime fees paid to the treasury by fee distribution
+func GetTotalFeesToTreasury() *big.Int {
+	return globalFeeLedger.TotalToTreasury()
+}
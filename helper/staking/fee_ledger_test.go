@@ -0,0 +1,118 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestFeeLedgerAccumulatesDistributedFees(t *testing.T) {
+	defer func() {
+		globalFeeLedger = NewFeeLedger()
+		resetStagedTxFees()
+	}()
+
+	globalFeeLedger = NewFeeLedger()
+	resetStagedTxFees()
+
+	var (
+		owner    = types.StringToAddress(testOwnerAddress)
+		producer = types.StringToAddress("0x2")
+	)
+
+	txn := newMockTxn()
+
+	if err := DistributeTxFeesToValidator(txn, big.NewInt(101), owner, producer, 1, testBlockHash(1), types.StringToHash("0x1")); err != nil {
+		t.Fatalf("Failed to distribute fees: %v", err)
+	}
+
+	if err := CommitBlockFeeEffects(1, testBlockHash(1)); err != nil {
+		t.Fatalf("Failed to commit fee effects: %v", err)
+	}
+
+	if err := DistributeTxFeesToValidator(txn, big.NewInt(10), owner, producer, 2, testBlockHash(2), types.StringToHash("0x2")); err != nil {
+		t.Fatalf("Failed to distribute fees: %v", err)
+	}
+
+	if err := CommitBlockFeeEffects(2, testBlockHash(2)); err != nil {
+		t.Fatalf("Failed to commit fee effects: %v", err)
+	}
+
+	if got := GetFeesEarned(owner); got.Cmp(big.NewInt(55)) != 0 {
+		t.Errorf("Expected owner to have earned 55, got %s", got.String())
+	}
+
+	if got := GetFeesEarned(producer); got.Cmp(big.NewInt(56)) != 0 {
+		t.Errorf("Expected producer to have earned 56, got %s", got.String())
+	}
+
+	if got := GetFeesEarned(types.StringToAddress("0x3")); got.Sign() != 0 {
+		t.Errorf("Expected unknown address to have earned 0, got %s", got.String())
+	}
+
+	earners := GetAllFeeEarners()
+	if len(earners) != 2 {
+		t.Fatalf("Expected 2 fee earners, got %d", len(earners))
+	}
+
+	// The returned map is a copy
+	earners[owner].SetInt64(0)
+
+	if got := GetFeesEarned(owner); got.Cmp(big.NewInt(55)) != 0 {
+		t.Errorf("Expected ledger to be unaffected by callers, got %s", got.String())
+	}
+}
+
+func TestFeeLedgerTotalFeesDistributed(t *testing.T) {
+	defer func() {
+		globalFeeLedger = NewFeeLedger()
+		resetStagedTxFees()
+	}()
+
+	globalFeeLedger = NewFeeLedger()
+	resetStagedTxFees()
+
+	var (
+		owner     = types.StringToAddress(testOwnerAddress)
+		producerA = types.StringToAddress("0x2")
+		producerB = types.StringToAddress("0x3")
+	)
+
+	txn := newMockTxn()
+
+	if err := DistributeTxFeesToValidator(txn, big.NewInt(101), owner, producerA, 1, testBlockHash(1), types.StringToHash("0x1")); err != nil {
+		t.Fatalf("Failed to distribute fees: %v", err)
+	}
+
+	if err := CommitBlockFeeEffects(1, testBlockHash(1)); err != nil {
+		t.Fatalf("Failed to commit fee effects: %v", err)
+	}
+
+	if err := DistributeTxFeesToValidator(txn, big.NewInt(10), owner, producerB, 2, testBlockHash(2), types.StringToHash("0x2")); err != nil {
+		t.Fatalf("Failed to distribute fees: %v", err)
+	}
+
+	if err := CommitBlockFeeEffects(2, testBlockHash(2)); err != nil {
+		t.Fatalf("Failed to commit fee effects: %v", err)
+	}
+
+	if got := GetTotalFeesToOwner(); got.Cmp(big.NewInt(55)) != 0 {
+		t.Errorf("Expected 55 paid to the owner, got %s", got.String())
+	}
+
+	if got := GetTotalFeesToProducers(); got.Cmp(big.NewInt(56)) != 0 {
+		t.Errorf("Expected 56 paid to producers, got %s", got.String())
+	}
+
+	if got := GetTotalFeesDistributed(); got.Cmp(big.NewInt(111)) != 0 {
+		t.Errorf("Expected 111 distributed in total, got %s", got.String())
+	}
+
+	// The returned totals are copies
+	GetTotalFeesToOwner().SetInt64(0)
+
+	if got := GetTotalFeesToOwner(); got.Cmp(big.NewInt(55)) != 0 {
+		t.Errorf("Expected ledger to be unaffected by callers, got %s", got.String())
+	}
+}
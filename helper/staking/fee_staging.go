@@ -0,0 +1,262 @@
+package staking
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Transactions are executed several times before their block is final (building,
+// verification, tracing), and several proposals may be executed at the same
+// height, e.g. after an IBFT round change. The supply-side effects of fee
+// distribution are therefore staged per (block hash, tx) while executing and only
+// recorded for the block actually inserted, by CommitBlockFeeEffects
+var (
+	stagedTxFees       = make(map[types.Hash]*stagedBlockFees)
+	lastFeeCommitBlock uint64
+	feesCommitted      bool
+	stagedTxFeesLock   sync.Mutex
+)
+
+// stagedBlockFees holds the fee effects staged while executing one block
+type stagedBlockFees struct {
+	blockNumber uint64
+	txs         map[types.Hash]*txFeeEffects
+	// Fees added by AccumulateTxFees per transaction, until FlushBlockFees splits them
+	accumulated map[types.Hash]*big.Int
+}
+
+// feePayoutKind tells which fee ledger total a payout counts towards
+type feePayoutKind int
+
+const (
+	ownerFeePayout feePayoutKind = iota
+	producerFeePayout
+	treasuryFeePayout
+)
+
+// feePayout is a fee credited to an address, to be recorded in the fee ledger
+type feePayout struct {
+	addr   types.Address
+	amount *big.Int
+	kind   feePayoutKind
+}
+
+// txFeeEffects holds the supply-side effects of distributing one transaction's fees
+type txFeeEffects struct {
+	zeroAddressBurn *big.Int
+	pausedBurn      *big.Int
+	payouts         []feePayout
+}
+
+// stageTxFeeEffects stores the fee effects of a transaction executed as part of
+// the block with the given hash, replacing the ones staged by an earlier execution
+// of the same transaction in the same block. Executions of already committed
+// heights, such as tracing, are ignored.
+func stageTxFeeEffects(blockNumber uint64, blockHash, txHash types.Hash, effects *txFeeEffects) {
+	stagedTxFeesLock.Lock()
+	defer stagedTxFeesLock.Unlock()
+
+	if feesCommitted && blockNumber <= lastFeeCommitBlock {
+		return
+	}
+
+	stagedBlockLocked(blockNumber, blockHash).txs[txHash] = effects
+}
+
+// stagedBlockLocked returns the staged fees of the block with the given hash,
+// creating them if needed. The caller must hold stagedTxFeesLock.
+func stagedBlockLocked(blockNumber uint64, blockHash types.Hash) *stagedBlockFees {
+	block, ok := stagedTxFees[blockHash]
+	if !ok {
+		block = &stagedBlockFees{
+			blockNumber: blockNumber,
+			txs:         make(map[types.Hash]*txFeeEffects),
+			accumulated: make(map[types.Hash]*big.Int),
+		}
+		stagedTxFees[blockHash] = block
+	}
+
+	return block
+}
+
+// AccumulateTxFees adds a transaction's fees to the total of the block with the
+// given hash, to be split once by FlushBlockFees instead of per transaction, so
+// the rounding of the split and the owner fee floor apply once per block. Fees
+// are kept per transaction, so executing a transaction again replaces its fees
+// instead of adding them twice. Executions of already committed heights are
+// ignored.
+func AccumulateTxFees(blockNumber uint64, blockHash, txHash types.Hash, txFees *big.Int) {
+	if txFees == nil || txFees.Sign() <= 0 {
+		return
+	}
+
+	stagedTxFeesLock.Lock()
+	defer stagedTxFeesLock.Unlock()
+
+	if feesCommitted && blockNumber <= lastFeeCommitBlock {
+		return
+	}
+
+	stagedBlockLocked(blockNumber, blockHash).accumulated[txHash] = new(big.Int).Set(txFees)
+}
+
+// FlushBlockFees distributes the fees accumulated by AccumulateTxFees for the block
+// with the given hash with a single DistributeTxFeesToValidator split, staged
+// under the block hash, and resets the block's accumulator. If the distribution
+// fails the fees are kept, so they are included in the next flush.
+func FlushBlockFees(
+	txn interface{ AddBalance(types.Address, *big.Int) },
+	ownerAddress types.Address,
+	blockProducerAddress types.Address,
+	blockNumber uint64,
+	blockHash types.Hash,
+) error {
+	stagedTxFeesLock.Lock()
+
+	blockFees := big.NewInt(0)
+	if block, ok := stagedTxFees[blockHash]; ok {
+		for _, txFees := range block.accumulated {
+			blockFees.Add(blockFees, txFees)
+		}
+	}
+
+	stagedTxFeesLock.Unlock()
+
+	if err := DistributeTxFeesToValidator(
+		txn,
+		blockFees,
+		ownerAddress,
+		blockProducerAddress,
+		blockNumber,
+		blockHash,
+		blockHash,
+	); err != nil {
+		return err
+	}
+
+	stagedTxFeesLock.Lock()
+	defer stagedTxFeesLock.Unlock()
+
+	if block, ok := stagedTxFees[blockHash]; ok {
+		block.accumulated = make(map[types.Hash]*big.Int)
+	}
+
+	return nil
+}
+
+// resetStagedTxFees drops all staged fee effects and forgets the last committed block
+func resetStagedTxFees() {
+	stagedTxFeesLock.Lock()
+	defer stagedTxFeesLock.Unlock()
+
+	stagedTxFees = make(map[types.Hash]*stagedBlockFees)
+	lastFeeCommitBlock = 0
+	feesCommitted = false
+}
+
+// CommitBlockFeeEffects records the fee effects staged for the inserted block with
+// the given hash: the payouts in the fee ledger and the burns in the global supply
+// tracker, with one burn entry per burn reason. Effects staged for other blocks at
+// or below its height, such as proposals of earlier rounds, are discarded.
+// Committing a block at or below the last committed one is a no-op, so every
+// height is accounted for exactly once.
+func CommitBlockFeeEffects(blockNumber uint64, blockHash types.Hash) error {
+	stagedTxFeesLock.Lock()
+
+	if feesCommitted && blockNumber <= lastFeeCommitBlock {
+		stagedTxFeesLock.Unlock()
+
+		return nil
+	}
+
+	zeroAddressBurn := big.NewInt(0)
+	pausedBurn := big.NewInt(0)
+	payouts := make([]feePayout, 0)
+
+	if block, ok := stagedTxFees[blockHash]; ok && block.blockNumber == blockNumber {
+		for _, effects := range block.txs {
+			zeroAddressBurn.Add(zeroAddressBurn, effects.zeroAddressBurn)
+			pausedBurn.Add(pausedBurn, effects.pausedBurn)
+			payouts = append(payouts, effects.payouts...)
+		}
+	}
+
+	for hash, block := range stagedTxFees {
+		if block.blockNumber <= blockNumber {
+			delete(stagedTxFees, hash)
+		}
+	}
+
+	lastFeeCommitBlock = blockNumber
+	feesCommitted = true
+
+	stagedTxFeesLock.Unlock()
+
+	for _, payout := range payouts {
+		switch payout.kind {
+		case ownerFeePayout:
+			globalFeeLedger.RecordOwnerFee(payout.addr, payout.amount)
+		case producerFeePayout:
+			globalFeeLedger.RecordProducerFee(payout.addr, payout.amount)
+		case treasuryFeePayout:
+			globalFeeLedger.RecordTreasuryFee(payout.addr, payout.amount)
+		}
+	}
+
+	return GetGlobalSupplyTracker().tracker.recordFeeBurns(blockNumber, zeroAddressBurn, pausedBurn)
+}
+
+// recordFeeBurns records the zero address and paused payout fee burns of a block.
+// The burns are recorded after the block's reward mint, so with strict block
+// order they may share the block number of the last entry but not precede it.
+func (st *SupplyTracker) recordFeeBurns(blockNumber uint64, zeroAddressBurn, pausedBurn *big.Int) error {
+	total := new(big.Int).Add(zeroAddressBurn, pausedBurn)
+	if total.Sign() == 0 {
+		return nil
+	}
+
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	if err := st.checkFrozenLocked(total, blockNumber); err != nil {
+		return err
+	}
+
+	if st.strictBlockOrder {
+		if last, ok := st.lastBlockLocked(); ok && blockNumber < last {
+			return fmt.Errorf("%w: block %d, last recorded block %d", ErrNonMonotonicBlock, blockNumber, last)
+		}
+	}
+
+	if st.getCurrentSupply().Cmp(total) < 0 {
+		return newSupplyError(ErrInsufficientSupply, blockNumber, total)
+	}
+
+	burns := []struct {
+		amount *big.Int
+		reason string
+	}{
+		{zeroAddressBurn, ReasonZeroAddressFee},
+		{pausedBurn, ReasonPausedPayout},
+	}
+
+	for _, burn := range burns {
+		if burn.amount.Sign() == 0 {
+			continue
+		}
+
+		st.appendEntryLocked(SupplyAuditLog{
+			BlockNumber: blockNumber,
+			Amount:      burn.amount,
+			Type:        ChangeBurn,
+			Timestamp:   st.entryTimestamp(blockNumber),
+			Caller:      consensusEngineCaller,
+			Reason:      burn.reason,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package staking
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestCommitBlockFeeEffectsRecordsEachBlockOnce(t *testing.T) {
+	defer func() {
+		SetFeeConfig(FeeConfig{})
+		InitializeSupplyTracker(big.NewInt(0))
+		resetStagedTxFees()
+		globalFeeLedger = NewFeeLedger()
+	}()
+
+	var (
+		owner    = types.StringToAddress(testOwnerAddress)
+		producer = types.StringToAddress("0x2")
+		txA      = types.StringToHash("0xa")
+		txB      = types.StringToHash("0xb")
+		txC      = types.StringToHash("0xc")
+		blockA   = types.StringToHash("0xa5")
+		blockB   = types.StringToHash("0xb5")
+	)
+
+	InitializeSupplyTracker(big.NewInt(1000))
+	GetGlobalSupplyTracker().tracker.SetStrictBlockOrdering(true)
+	SetFeeConfig(FeeConfig{PauseOwnerPayout: true})
+	resetStagedTxFees()
+
+	globalFeeLedger = NewFeeLedger()
+
+	// The block reward is minted before the block's fee effects are committed
+	if err := GetGlobalSupplyTracker().tracker.Mint(big.NewInt(10), 5, "consensus_engine"); err != nil {
+		t.Fatalf("Failed to mint: %v", err)
+	}
+
+	// Building and verifying the inserted block executes its transactions twice
+	for i := 0; i < 2; i++ {
+		for _, txHash := range []types.Hash{txA, txB} {
+			if err := DistributeTxFeesToValidator(newMockTxn(), big.NewInt(100), owner, producer, 5, blockA, txHash); err != nil {
+				t.Fatalf("Failed to distribute fees: %v", err)
+			}
+		}
+	}
+
+	// A proposal of an earlier round at the same height executes the same
+	// transactions with other fees, plus txC
+	for _, txHash := range []types.Hash{txA, txB, txC} {
+		if err := DistributeTxFeesToValidator(newMockTxn(), big.NewInt(300), owner, producer, 5, blockB, txHash); err != nil {
+			t.Fatalf("Failed to distribute fees: %v", err)
+		}
+	}
+
+	if err := CommitBlockFeeEffects(5, blockA); err != nil {
+		t.Fatalf("Failed to commit fee effects: %v", err)
+	}
+
+	// Committing the block again and re-executing it, as tracing does, change nothing
+	if err := CommitBlockFeeEffects(5, blockA); err != nil {
+		t.Fatalf("Failed to recommit fee effects: %v", err)
+	}
+
+	if err := DistributeTxFeesToValidator(newMockTxn(), big.NewInt(100), owner, producer, 5, blockA, txA); err != nil {
+		t.Fatalf("Failed to distribute fees: %v", err)
+	}
+
+	// The discarded proposal is not committed along with a later block either
+	if err := CommitBlockFeeEffects(6, blockB); err != nil {
+		t.Fatalf("Failed to commit fee effects: %v", err)
+	}
+
+	log := GetSupplyAuditLog()
+	if len(log) != 2 {
+		t.Fatalf("Expected a mint and a single burn entry, got %+v", log)
+	}
+
+	burn := log[1]
+	if burn.Type != ChangeBurn || burn.BlockNumber != 5 ||
+		burn.Reason != ReasonPausedPayout || burn.Amount.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("Unexpected burn entry: %+v", burn)
+	}
+
+	if got := GetCurrentSupply(); got.Cmp(big.NewInt(910)) != 0 {
+		t.Errorf("Expected supply 910, got %s", got.String())
+	}
+
+	// Only the producer shares of the two included transactions are paid
+	if got := GetFeesEarned(producer); got.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("Expected producer to have earned 100, got %s", got.String())
+	}
+
+	if got := GetTotalFeesDistributed(); got.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("Expected 100 distributed in total, got %s", got.String())
+	}
+}
+
+func TestFlushBlockFees(t *testing.T) {
+	defer func() {
+		SetFeeConfig(FeeConfig{})
+		InitializeSupplyTracker(big.NewInt(0))
+		resetStagedTxFees()
+		globalFeeLedger = NewFeeLedger()
+	}()
+
+	var (
+		owner    = types.StringToAddress(testOwnerAddress)
+		producer = types.StringToAddress("0x2")
+		txs      = []types.Hash{types.StringToHash("0xa"), types.StringToHash("0xb"), types.StringToHash("0xc")}
+	)
+
+	InitializeSupplyTracker(big.NewInt(1000))
+	resetStagedTxFees()
+
+	globalFeeLedger = NewFeeLedger()
+
+	txn := newMockTxn()
+
+	// Three 3 wei fees lose a wei each when split per transaction, but not when
+	// split once per block. Executing the block twice does not count them twice.
+	for i := 0; i < 2; i++ {
+		for _, txHash := range txs {
+			AccumulateTxFees(1, testBlockHash(1), txHash, big.NewInt(3))
+		}
+	}
+
+	if err := FlushBlockFees(txn, owner, producer, 1, testBlockHash(1)); err != nil {
+		t.Fatalf("Failed to flush block fees: %v", err)
+	}
+
+	if txn.GetBalance(owner).Cmp(big.NewInt(4)) != 0 {
+		t.Errorf("Expected owner to receive 4, got %s", txn.GetBalance(owner).String())
+	}
+
+	if txn.GetBalance(producer).Cmp(big.NewInt(5)) != 0 {
+		t.Errorf("Expected producer to receive 5, got %s", txn.GetBalance(producer).String())
+	}
+
+	// The accumulator is reset, so flushing again distributes nothing
+	if err := FlushBlockFees(txn, owner, producer, 1, testBlockHash(1)); err != nil {
+		t.Fatalf("Failed to flush block fees: %v", err)
+	}
+
+	if total := new(big.Int).Add(txn.GetBalance(owner), txn.GetBalance(producer)); total.Cmp(big.NewInt(9)) != 0 {
+		t.Errorf("Expected 9 distributed in total, got %s", total.String())
+	}
+
+	// Fees accumulated for another proposal at the same height are not flushed
+	AccumulateTxFees(1, types.StringToHash("0xb1"), txs[0], big.NewInt(50))
+
+	if err := CommitBlockFeeEffects(1, testBlockHash(1)); err != nil {
+		t.Fatalf("Failed to commit fee effects: %v", err)
+	}
+
+	if got := GetFeesEarned(producer); got.Cmp(big.NewInt(5)) != 0 {
+		t.Errorf("Expected producer to have earned 5, got %s", got.String())
+	}
+
+	if got := GetTotalFeesDistributed(); got.Cmp(big.NewInt(9)) != 0 {
+		t.Errorf("Expected 9 distributed in total, got %s", got.String())
+	}
+
+	// A failed flush keeps the fees for the next one
+	AccumulateTxFees(2, testBlockHash(2), txs[0], big.NewInt(10))
+
+	if err := FlushBlockFees(txn, types.ZeroAddress, producer, 2, testBlockHash(2)); !errors.Is(err, ErrZeroAddressRecipient) {
+		t.Fatalf("Expected ErrZeroAddressRecipient, got %v", err)
+	}
+
+	if err := FlushBlockFees(txn, owner, producer, 2, testBlockHash(2)); err != nil {
+		t.Fatalf("Failed to flush block fees: %v", err)
+	}
+
+	if txn.GetBalance(owner).Cmp(big.NewInt(9)) != 0 {
+		t.Errorf("Expected owner to receive the retained fees, got %s", txn.GetBalance(owner).String())
+	}
+}
+
+func TestFlushBlockFeesOwnerFeeFloor(t *testing.T) {
+	defer func() {
+		SetFeeConfig(FeeConfig{})
+		resetStagedTxFees()
+	}()
+
+	var (
+		owner    = types.StringToAddress(testOwnerAddress)
+		producer = types.StringToAddress("0x2")
+	)
+
+	SetFeeConfig(FeeConfig{OwnerFeeFloor: big.NewInt(5)})
+	resetStagedTxFees()
+
+	txn := newMockTxn()
+
+	// Split per transaction, each 3 wei fee is below the floor and goes to the
+	// owner entirely. Split per block, the floor applies once to the 9 wei total.
+	for _, txHash := range []types.Hash{types.StringToHash("0xa"), types.StringToHash("0xb"), types.StringToHash("0xc")} {
+		AccumulateTxFees(1, testBlockHash(1), txHash, big.NewInt(3))
+	}
+
+	if err := FlushBlockFees(txn, owner, producer, 1, testBlockHash(1)); err != nil {
+		t.Fatalf("Failed to flush block fees: %v", err)
+	}
+
+	if txn.GetBalance(owner).Cmp(big.NewInt(5)) != 0 {
+		t.Errorf("Expected owner to receive the floor of 5, got %s", txn.GetBalance(owner).String())
+	}
+
+	if txn.GetBalance(producer).Cmp(big.NewInt(4)) != 0 {
+		t.Errorf("Expected producer to receive 4, got %s", txn.GetBalance(producer).String())
+	}
+}
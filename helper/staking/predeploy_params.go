@@ -0,0 +1,55 @@
+package staking
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// PredeployOption customizes the PredeployParams built by NewPredeployParams
+type PredeployOption func(*PredeployParams)
+
+// WithValidatorRange sets the minimum and maximum number of validators
+func WithValidatorRange(minCount, maxCount uint64) PredeployOption {
+	return func(p *PredeployParams) {
+		p.MinValidatorCount = minCount
+		p.MaxValidatorCount = maxCount
+	}
+}
+
+// WithMinValidatorStake sets the per-validator stake floor checked on predeployment
+func WithMinValidatorStake(minStake *big.Int) PredeployOption {
+	return func(p *PredeployParams) {
+		if minStake == nil {
+			p.MinValidatorStake = nil
+
+			return
+		}
+
+		p.MinValidatorStake = new(big.Int).Set(minStake)
+	}
+}
+
+// NewPredeployParams builds PredeployParams for the given owner, starting from the
+// default validator range of MinValidatorCount to MaxValidatorCount, and validates
+// the result with ValidateStakingParams before returning it
+func NewPredeployParams(owner string, opts ...PredeployOption) (PredeployParams, error) {
+	params := PredeployParams{
+		MinValidatorCount: MinValidatorCount,
+		MaxValidatorCount: MaxValidatorCount,
+		OwnerAddress:      owner,
+	}
+
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	if err := ValidateStakingParams(params); err != nil {
+		return PredeployParams{}, err
+	}
+
+	if params.MinValidatorStake != nil && params.MinValidatorStake.Sign() < 0 {
+		return PredeployParams{}, fmt.Errorf("MinValidatorStake cannot be negative")
+	}
+
+	return params, nil
+}
@@ -0,0 +1,46 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestNewPredeployParams(t *testing.T) {
+	params, err := NewPredeployParams(testOwnerAddress)
+	if err != nil {
+		t.Fatalf("Expected default params to be valid: %v", err)
+	}
+
+	if params.MinValidatorCount != MinValidatorCount || params.MaxValidatorCount != MaxValidatorCount {
+		t.Errorf("Expected default validator range, got %d-%d", params.MinValidatorCount, params.MaxValidatorCount)
+	}
+
+	params, err = NewPredeployParams(
+		testOwnerAddress,
+		WithValidatorRange(4, 10),
+		WithMinValidatorStake(big.NewInt(1000)),
+	)
+	if err != nil {
+		t.Fatalf("Expected custom params to be valid: %v", err)
+	}
+
+	if params.MinValidatorCount != 4 || params.MaxValidatorCount != 10 || params.MinValidatorStake.Int64() != 1000 {
+		t.Errorf("Unexpected params: %+v", params)
+	}
+
+	invalid := [][]PredeployOption{
+		{WithValidatorRange(0, 10)},
+		{WithValidatorRange(5, 4)},
+		{WithMinValidatorStake(big.NewInt(-1))},
+	}
+
+	for i, opts := range invalid {
+		if _, err := NewPredeployParams(testOwnerAddress, opts...); err == nil {
+			t.Errorf("Expected options %d to be rejected", i)
+		}
+	}
+
+	if _, err := NewPredeployParams(""); err == nil {
+		t.Error("Expected an empty owner to be rejected")
+	}
+}
@@ -133,6 +133,8 @@ type PredeployParams struct {
 	MinValidatorCount uint64
 	MaxValidatorCount uint64
 	OwnerAddress      string
+	// MinValidatorStake is the contract's per-validator stake floor, nil to skip the check
+	MinValidatorStake *big.Int
 }
 
 // StorageIndexes is a wrapper for different storage indexes that
@@ -175,9 +177,8 @@ func PredeployStakingSC(
 	// Parse owner address
 	ownerAddr := types.StringToAddress(params.OwnerAddress)
 
-	fmt.Printf("[DEBUG] minNumValidators: %d\n", params.MinValidatorCount)
-	fmt.Printf("[DEBUG] maxNumValidators: %d\n", params.MaxValidatorCount)
-	fmt.Printf("[DEBUG] owner: %s\n", params.OwnerAddress)
+	getSupplyLogger().Debug("predeploying staking contract", "minNumValidators", params.MinValidatorCount,
+		"maxNumValidators", params.MaxValidatorCount, "owner", params.OwnerAddress)
 
 	// Use the deployedBytecode (runtime code) instead of full bytecode with constructor
 	// This avoids "execution reverted" during genesis as we set storage manually
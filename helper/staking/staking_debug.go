@@ -3,6 +3,7 @@ package staking
 import (
 	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/common"
@@ -13,24 +14,36 @@ import (
 
 // ValidateStakingParams validates the staking contract parameters before deployment
 func ValidateStakingParams(params PredeployParams) error {
+	if errs := stakingParamErrors(params); len(errs) > 0 {
+		return errs[0]
+	}
+
+	return nil
+}
+
+// stakingParamErrors returns every problem with the staking contract parameters
+func stakingParamErrors(params PredeployParams) []error {
+	var errs []error
+
 	if params.MinValidatorCount == 0 {
-		return fmt.Errorf("MinValidatorCount cannot be zero")
+		errs = append(errs, fmt.Errorf("MinValidatorCount cannot be zero"))
 	}
+
 	if params.MaxValidatorCount < params.MinValidatorCount {
-		return fmt.Errorf("MaxValidatorCount (%d) cannot be less than MinValidatorCount (%d)",
-			params.MaxValidatorCount, params.MinValidatorCount)
+		errs = append(errs, fmt.Errorf("MaxValidatorCount (%d) cannot be less than MinValidatorCount (%d)",
+			params.MaxValidatorCount, params.MinValidatorCount))
 	}
+
 	if params.OwnerAddress == "" {
-		return fmt.Errorf("OwnerAddress cannot be empty")
-	}
-	ownerAddr := types.StringToAddress(params.OwnerAddress)
-	if ownerAddr == types.ZeroAddress {
-		return fmt.Errorf("OwnerAddress cannot be zero address")
+		errs = append(errs, fmt.Errorf("OwnerAddress cannot be empty"))
+	} else if types.StringToAddress(params.OwnerAddress) == types.ZeroAddress {
+		errs = append(errs, fmt.Errorf("OwnerAddress cannot be zero address"))
 	}
-	return nil
+
+	return errs
 }
 
-// DebugConstructorData prints the constructor data for debugging
+// DebugConstructorData logs the constructor data at debug level for debugging
 func DebugConstructorData(params PredeployParams) {
 	ownerAddr := types.StringToAddress(params.OwnerAddress)
 	minValidatorsBig := new(big.Int).SetUint64(params.MinValidatorCount)
@@ -41,20 +54,15 @@ func DebugConstructorData(params PredeployParams) {
 	copy(constructorData[32:64], common.PadLeftOrTrim(maxValidatorsBig.Bytes(), 32))
 	copy(constructorData[64:96], common.PadLeftOrTrim(ownerAddr.Bytes(), 32))
 
-	fmt.Printf("=== STAKING CONTRACT DEBUG INFO ===\n")
-	fmt.Printf("MinValidatorCount: %d (0x%x)\n", params.MinValidatorCount, minValidatorsBig.Bytes())
-	fmt.Printf("MaxValidatorCount: %d (0x%x)\n", params.MaxValidatorCount, maxValidatorsBig.Bytes())
-	fmt.Printf("OwnerAddress: %s (0x%x)\n", params.OwnerAddress, ownerAddr.Bytes())
-	fmt.Printf("Constructor Data (96 bytes): 0x%x\n", constructorData)
-	fmt.Printf("Bytecode Length: %d bytes\n", len(StakingSCBytecode)/2)
+	getSupplyLogger().Debug("staking contract constructor data",
+		"minValidatorCount", params.MinValidatorCount, "maxValidatorCount", params.MaxValidatorCount,
+		"owner", params.OwnerAddress, "constructorData", hex.EncodeToHex(constructorData),
+		"bytecodeLength", len(StakingSCBytecode)/2)
 
 	// Validate bytecode can be decoded
 	if _, err := hex.DecodeHex(StakingSCBytecode); err != nil {
-		fmt.Printf("ERROR: Failed to decode bytecode: %v\n", err)
-	} else {
-		fmt.Printf("Bytecode validation: OK\n")
+		getSupplyLogger().Warn("failed to decode staking contract bytecode", "err", err)
 	}
-	fmt.Printf("===================================\n")
 }
 
 // CreateFallbackStakingAccount creates a staking account without constructor (fallback mode)
@@ -92,67 +100,204 @@ func ValidateStakingPredeployment(
 	vals validators.Validators,
 	params PredeployParams,
 ) (*chain.GenesisAccount, error) {
-	fmt.Println("--- Validating Staking Contract Predeployment ---")
+	if err := validatePredeployment(vals, params); err != nil {
+		return nil, err
+	}
 
-	// 1. Validate Core Parameters
-	if params.MinValidatorCount == 0 {
-		return nil, fmt.Errorf("validation failed: MinValidatorCount cannot be zero")
+	// Attempt to predeploy the staking SC
+	account, err := PredeployStakingSC(vals, params)
+	if err != nil {
+		getSupplyLogger().Warn("staking predeployment failed, initializing storage without constructor",
+			"err", err)
+		// If the main predeployment fails, try to initialize storage manually without constructor args
+		// This is a fallback for debugging purposes
+		return predeployStakingSCFallback(vals, params)
 	}
-	if params.MaxValidatorCount < params.MinValidatorCount {
-		return nil, fmt.Errorf("validation failed: MaxValidatorCount (%d) cannot be less than MinValidatorCount (%d)",
-			params.MaxValidatorCount, params.MinValidatorCount)
+
+	return account, nil
+}
+
+// ValidateStakingPredeploymentVerbose behaves like ValidateStakingPredeployment and also
+// returns a copy of the storage map written to the staking account, whichever of the
+// primary or fallback paths produced it
+func ValidateStakingPredeploymentVerbose(
+	vals validators.Validators,
+	params PredeployParams,
+) (*chain.GenesisAccount, map[types.Hash]types.Hash, error) {
+	account, err := ValidateStakingPredeployment(vals, params)
+	if err != nil {
+		return nil, nil, err
 	}
-	if params.OwnerAddress == "" {
-		return nil, fmt.Errorf("validation failed: OwnerAddress cannot be empty")
+
+	storage := make(map[types.Hash]types.Hash, len(account.Storage))
+	for slot, value := range account.Storage {
+		storage[slot] = value
 	}
 
-	fmt.Printf("  [✔] Core parameters are valid.\n")
-	fmt.Printf("      - Min Validators: %d\n", params.MinValidatorCount)
-	fmt.Printf("      - Max Validators: %d\n", params.MaxValidatorCount)
-	fmt.Printf("      - Owner: %s\n", params.OwnerAddress)
+	getSupplyLogger().Debug("staking predeployment wrote storage", "slots", len(storage))
+
+	return account, storage, nil
+}
+
+// DryRunStakingPredeployment runs the same parameter and validator-set checks as
+// ValidateStakingPredeployment without building any genesis account
+func DryRunStakingPredeployment(vals validators.Validators, params PredeployParams) error {
+	return validatePredeployment(vals, params)
+}
+
+// ValidateStakingPredeploymentAll runs the checks of ValidateStakingPredeployment
+// without stopping at the first failure and returns every problem found, so large
+// genesis files can be fixed in one pass. A nil result means the predeployment is valid.
+func ValidateStakingPredeploymentAll(vals validators.Validators, params PredeployParams) []error {
+	return predeploymentErrors(vals, params)
+}
+
+// predeploymentErrors returns every problem with the staking parameters and the
+// initial validator set, in the order the single-error path reports them
+func predeploymentErrors(vals validators.Validators, params PredeployParams) []error {
+	var errs []error
+
+	for _, err := range stakingParamErrors(params) {
+		errs = append(errs, fmt.Errorf("validation failed: %w", err))
+	}
 
-	// 2. Validate Validator Set
 	if vals == nil || vals.Len() == 0 {
-		fmt.Println("  [!] Warning: No initial validators provided in the genesis file.")
-	} else {
-		if uint64(vals.Len()) < params.MinValidatorCount {
-			return nil, fmt.Errorf("validation failed: not enough validators. Have %d, need at least %d",
-				vals.Len(), params.MinValidatorCount)
-		}
-		if uint64(vals.Len()) > params.MaxValidatorCount {
-			return nil, fmt.Errorf("validation failed: too many validators. Have %d, max allowed %d",
-				vals.Len(), params.MaxValidatorCount)
+		return errs
+	}
+
+	if uint64(vals.Len()) < params.MinValidatorCount {
+		errs = append(errs, fmt.Errorf("validation failed: not enough validators. Have %d, need at least %d",
+			vals.Len(), params.MinValidatorCount))
+	}
+
+	if uint64(vals.Len()) > params.MaxValidatorCount {
+		errs = append(errs, fmt.Errorf("validation failed: too many validators. Have %d, max allowed %d",
+			vals.Len(), params.MaxValidatorCount))
+	}
+
+	for idx := 0; idx < vals.Len(); idx++ {
+		if vals.At(uint64(idx)).Addr() == types.ZeroAddress {
+			errs = append(errs, fmt.Errorf("validation failed: validator %d has the zero address", idx))
 		}
-		fmt.Printf("  [✔] Validator set is valid with %d validators.\n", vals.Len())
 	}
 
-	fmt.Println("--- Predeployment validation successful ---")
+	if err := validateStakeAgainstGenesis(vals.Len()); err != nil {
+		errs = append(errs, err)
+	}
 
-	// Attempt to predeploy the staking SC
-	account, err := PredeployStakingSC(vals, params)
-	if err != nil {
-		fmt.Printf("--- Primary Predeployment FAILED: %v ---\n", err)
-		fmt.Println("--- Attempting Fallback: Initializing Storage Without Constructor ---")
-		// If the main predeployment fails, try to initialize storage manually without constructor args
-		// This is a fallback for debugging purposes
-		return predeployStakingSCFallback(vals, params)
+	if err := validateMinValidatorStake(vals, params.MinValidatorStake); err != nil {
+		errs = append(errs, err)
 	}
 
-	return account, nil
+	return errs
+}
+
+// validatePredeployment runs the staking predeployment checks, logs their results
+// and returns the first problem found
+func validatePredeployment(vals validators.Validators, params PredeployParams) error {
+	if vals == nil || vals.Len() == 0 {
+		getSupplyLogger().Warn("no initial validators provided in the genesis file")
+	}
+
+	if errs := predeploymentErrors(vals, params); len(errs) > 0 {
+		return errs[0]
+	}
+
+	validatorCount := 0
+	if vals != nil {
+		validatorCount = vals.Len()
+	}
+
+	getSupplyLogger().Debug("staking predeployment is valid", "minValidators", params.MinValidatorCount,
+		"maxValidators", params.MaxValidatorCount, "owner", params.OwnerAddress, "validators", validatorCount)
+
+	return nil
 }
 
 // predeployStakingSCFallback provides a simplified, manual storage initialization
 // for the staking contract, intended for debugging when the primary method fails.
+// It sets the runtime code and the storage computed by CreateFallbackStakingAccount
+// directly, so no constructor data is involved.
 func predeployStakingSCFallback(
 	vals validators.Validators,
 	params PredeployParams,
 ) (*chain.GenesisAccount, error) {
-	fmt.Println("--- Fallback Predeployment Initialized ---")
-	// This function would contain the core logic of setting storage slots manually
-	// without relying on constructor arguments being appended to the bytecode.
-	// This is a safeguard against "execution reverted" errors during genesis.
-
-	// For the purpose of this debug file, we'll just call the corrected primary function.
-	// In a real scenario, this could have a simplified, independent implementation.
-	return PredeployStakingSC(vals, params)
+	scHex, err := hex.DecodeHex(StakingSCBytecode)
+	if err != nil {
+		return nil, fmt.Errorf("fallback predeployment failed: unable to decode bytecode, %w", err)
+	}
+
+	_, storageMap, err := CreateFallbackStakingAccount(params)
+	if err != nil {
+		return nil, fmt.Errorf("fallback predeployment failed: %w", err)
+	}
+
+	if vals != nil && vals.Len() > 0 {
+		getSupplyLogger().Warn("fallback predeployment does not pre-stake the genesis validators",
+			"validators", vals.Len())
+	}
+
+	return &chain.GenesisAccount{
+		Code:    scHex,
+		Storage: storageMap,
+	}, nil
+}
+
+// validateStakeAgainstGenesis checks that the total stake required by the
+// initial validator set does not exceed the genesis supply
+func validateStakeAgainstGenesis(validatorCount int) error {
+	if GetGenesisAllocCache() == nil {
+		getSupplyLogger().Warn("genesis allocation not loaded, skipping stake supply check")
+
+		return nil
+	}
+
+	val := DefaultStakedBalance
+	stakePerValidator, err := common.ParseUint256orHex(&val)
+	if err != nil {
+		return fmt.Errorf("validation failed: unable to parse DefaultStakedBalance, %w", err)
+	}
+
+	totalStake := new(big.Int).Mul(big.NewInt(int64(validatorCount)), stakePerValidator)
+	genesisTotal := getGenesisTotal()
+
+	if totalStake.Cmp(genesisTotal) > 0 {
+		return fmt.Errorf("validation failed: total validator stake (%s wei) exceeds genesis supply (%s wei)",
+			totalStake.String(), genesisTotal.String())
+	}
+
+	getSupplyLogger().Debug("validator stake is covered by the genesis supply",
+		"stake", totalStake.String(), "genesisSupply", genesisTotal.String())
+
+	return nil
+}
+
+// validateMinValidatorStake checks that the stake the genesis validators are
+// predeployed with meets the contract's minimum stake, when one is configured.
+// Every genesis validator is staked with DefaultStakedBalance, so the stake is
+// checked once and the error names all the validators it applies to.
+func validateMinValidatorStake(vals validators.Validators, minStake *big.Int) error {
+	if minStake == nil {
+		return nil
+	}
+
+	val := DefaultStakedBalance
+	stakePerValidator, err := common.ParseUint256orHex(&val)
+	if err != nil {
+		return fmt.Errorf("validation failed: unable to parse DefaultStakedBalance, %w", err)
+	}
+
+	if stakePerValidator.Cmp(minStake) < 0 {
+		addrs := make([]string, vals.Len())
+		for idx := range addrs {
+			addrs[idx] = vals.At(uint64(idx)).Addr().String()
+		}
+
+		return fmt.Errorf("validation failed: validators %s stake (%s wei) is below the minimum stake (%s wei)",
+			strings.Join(addrs, ", "), stakePerValidator.String(), minStake.String())
+	}
+
+	getSupplyLogger().Debug("validators meet the minimum stake", "minStake", minStake.String())
+
+	return nil
 }
@@ -0,0 +1,204 @@
+package staking
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+)
+
+var testOwnerAddress = "0xBF67195527fAc3B20403eC806f362a621b19A7b5"
+
+func newTestValidators(count int) validators.Validators {
+	vals := make([]*validators.ECDSAValidator, count)
+	for i := 0; i < count; i++ {
+		vals[i] = validators.NewECDSAValidator(types.BytesToAddress([]byte{byte(i + 1)}))
+	}
+
+	return validators.NewECDSAValidatorSet(vals...)
+}
+
+func TestValidateStakingPredeploymentInsufficientGenesisSupply(t *testing.T) {
+	defer SetGenesisAllocCache(nil)
+
+	// 1 wei of genesis supply cannot cover even a single validator's stake
+	SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{
+		types.StringToAddress(testOwnerAddress): {Balance: big.NewInt(1)},
+	})
+
+	params := PredeployParams{
+		MinValidatorCount: 1,
+		MaxValidatorCount: 10,
+		OwnerAddress:      testOwnerAddress,
+	}
+
+	_, err := ValidateStakingPredeployment(newTestValidators(4), params)
+	if err == nil {
+		t.Fatal("Expected validation to fail when stake exceeds genesis supply")
+	}
+
+	if !strings.Contains(err.Error(), "exceeds genesis supply") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestDryRunStakingPredeployment(t *testing.T) {
+	params := PredeployParams{
+		MinValidatorCount: 1,
+		MaxValidatorCount: 2,
+		OwnerAddress:      testOwnerAddress,
+	}
+
+	if err := DryRunStakingPredeployment(newTestValidators(2), params); err != nil {
+		t.Fatalf("Expected dry run to pass, got %v", err)
+	}
+
+	err := DryRunStakingPredeployment(newTestValidators(3), params)
+	if err == nil || !strings.Contains(err.Error(), "too many validators") {
+		t.Errorf("Expected too many validators error, got %v", err)
+	}
+}
+
+func TestPredeployStakingSCFallback(t *testing.T) {
+	params := PredeployParams{
+		MinValidatorCount: 1,
+		MaxValidatorCount: 7,
+		OwnerAddress:      testOwnerAddress,
+	}
+
+	account, err := predeployStakingSCFallback(newTestValidators(1), params)
+	if err != nil {
+		t.Fatalf("Fallback predeployment failed: %v", err)
+	}
+
+	if len(account.Code) == 0 {
+		t.Error("Expected fallback account to have code")
+	}
+
+	minSlot := types.BytesToHash(big.NewInt(minNumValidatorSlot).Bytes())
+	if got := account.Storage[minSlot]; got != types.BytesToHash(big.NewInt(1).Bytes()) {
+		t.Errorf("Expected min validator slot to be 1, got %s", got)
+	}
+
+	maxSlot := types.BytesToHash(big.NewInt(maxNumValidatorSlot).Bytes())
+	if got := account.Storage[maxSlot]; got != types.BytesToHash(big.NewInt(7).Bytes()) {
+		t.Errorf("Expected max validator slot to be 7, got %s", got)
+	}
+}
+
+func TestValidateStakingPredeploymentVerbose(t *testing.T) {
+	params := PredeployParams{
+		MinValidatorCount: 1,
+		MaxValidatorCount: 4,
+		OwnerAddress:      testOwnerAddress,
+	}
+
+	account, storage, err := ValidateStakingPredeploymentVerbose(newTestValidators(2), params)
+	if err != nil {
+		t.Fatalf("Predeployment failed: %v", err)
+	}
+
+	if len(storage) != len(account.Storage) {
+		t.Fatalf("Expected %d storage slots, got %d", len(account.Storage), len(storage))
+	}
+
+	lengthSlot := types.BytesToHash(big.NewInt(validatorsSlot).Bytes())
+	if got := storage[lengthSlot]; got != types.BytesToHash(big.NewInt(2).Bytes()) {
+		t.Errorf("Expected validators array length 2, got %s", got)
+	}
+}
+
+func TestValidateStakingPredeploymentMinValidatorStake(t *testing.T) {
+	val := DefaultStakedBalance
+	stake, err := common.ParseUint256orHex(&val)
+	if err != nil {
+		t.Fatalf("Failed to parse default stake: %v", err)
+	}
+
+	params := PredeployParams{
+		MinValidatorCount: 1,
+		MaxValidatorCount: 4,
+		OwnerAddress:      testOwnerAddress,
+		MinValidatorStake: stake,
+	}
+
+	if err := DryRunStakingPredeployment(newTestValidators(2), params); err != nil {
+		t.Errorf("Expected validators at the floor to pass, got %v", err)
+	}
+
+	params.MinValidatorStake = new(big.Int).Add(stake, big.NewInt(1))
+
+	err = DryRunStakingPredeployment(newTestValidators(2), params)
+	if err == nil || !strings.Contains(err.Error(), "below the minimum stake") {
+		t.Fatalf("Expected minimum stake error, got %v", err)
+	}
+
+	if first := newTestValidators(1).At(0).Addr().String(); !strings.Contains(err.Error(), first) {
+		t.Errorf("Expected error to name validator %s, got %v", first, err)
+	}
+}
+
+func TestValidateStakingPredeploymentAll(t *testing.T) {
+	val := DefaultStakedBalance
+	stake, err := common.ParseUint256orHex(&val)
+	if err != nil {
+		t.Fatalf("Failed to parse default stake: %v", err)
+	}
+
+	params := PredeployParams{
+		MinValidatorCount: 1,
+		MaxValidatorCount: 2,
+		OwnerAddress:      testOwnerAddress,
+	}
+
+	if errs := ValidateStakingPredeploymentAll(newTestValidators(2), params); errs != nil {
+		t.Fatalf("Expected a valid predeployment, got %v", errs)
+	}
+
+	vals := validators.NewECDSAValidatorSet(
+		validators.NewECDSAValidator(types.StringToAddress("0x1")),
+		validators.NewECDSAValidator(types.ZeroAddress),
+		validators.NewECDSAValidator(types.StringToAddress("0x3")),
+	)
+
+	params.OwnerAddress = types.ZeroAddress.String()
+	params.MinValidatorStake = new(big.Int).Add(stake, big.NewInt(1))
+
+	errs := ValidateStakingPredeploymentAll(vals, params)
+
+	// Zero owner, too many validators, one zero-address validator and a stake
+	// below the minimum, reported once for the whole set
+	if len(errs) != 4 {
+		t.Fatalf("Expected 4 errors, got %d: %v", len(errs), errs)
+	}
+
+	for _, expected := range []string{
+		"OwnerAddress cannot be zero address",
+		"too many validators",
+		"validator 1 has the zero address",
+		"below the minimum stake",
+	} {
+		found := false
+
+		for _, err := range errs {
+			if strings.Contains(err.Error(), expected) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			t.Errorf("Expected an error containing %q, got %v", expected, errs)
+		}
+	}
+
+	// The single-error path stops at the first failure of the same checks
+	if err := DryRunStakingPredeployment(vals, params); err == nil || err.Error() != errs[0].Error() {
+		t.Errorf("Expected the dry run to fail with %v, got %v", errs[0], err)
+	}
+}
@@ -0,0 +1,219 @@
+package staking
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+const (
+	// auditFrameHeaderSize is the size of the big-endian length prefix of a frame
+	auditFrameHeaderSize = 4
+	// maxAuditFrameSize bounds the payload of a single frame when decoding
+	maxAuditFrameSize = 1 << 20
+	// auditEntryFields is the number of RLP list items in an encoded entry
+	auditEntryFields = 8
+	// legacyAuditEntryFields is the number of items in entries encoded before
+	// prevHash was added, which decode with a zero PrevHash
+	legacyAuditEntryFields = 7
+)
+
+var ErrInvalidAuditFrame = errors.New("invalid audit log frame")
+
+// The binary audit log is a sequence of frames, one per entry. Each frame is a
+// 4-byte big-endian payload length followed by the RLP list
+// [blockNumber, amount, type, timestamp, caller, reason, recipient, prevHash],
+// where recipient is empty when unset. Frames can be appended to a file and decoded
+// incrementally by a consumer tailing it.
+
+// MarshalAuditLogBinary encodes the whole audit log in the binary frame format
+func (st *SupplyTracker) MarshalAuditLogBinary() ([]byte, error) {
+	return st.MarshalAuditLogBinaryCtx(context.Background())
+}
+
+// MarshalAuditLogBinaryCtx is MarshalAuditLogBinary that stops early with the
+// context error once ctx is done
+func (st *SupplyTracker) MarshalAuditLogBinaryCtx(ctx context.Context) ([]byte, error) {
+	var buf bytes.Buffer
+
+	for i, entry := range st.GetAuditLog() {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := AppendAuditEntryBinary(&buf, entry); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// AppendAuditEntryBinary writes a single entry to w as one binary frame
+func AppendAuditEntryBinary(w io.Writer, entry SupplyAuditLog) error {
+	if entry.Amount == nil || entry.Amount.Sign() < 0 {
+		return fmt.Errorf("%w: entry at block %d has an invalid amount", ErrInvalidAuditFrame, entry.BlockNumber)
+	}
+
+	ar := fastrlp.DefaultArenaPool.Get()
+	defer fastrlp.DefaultArenaPool.Put(ar)
+
+	payload := encodeAuditEntry(ar, entry).MarshalTo(nil)
+
+	frame := make([]byte, auditFrameHeaderSize, auditFrameHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(frame, uint32(len(payload)))
+	frame = append(frame, payload...)
+
+	_, err := w.Write(frame)
+
+	return err
+}
+
+// encodeAuditEntry returns the RLP list of a single entry, the payload of its frame
+func encodeAuditEntry(ar *fastrlp.Arena, entry SupplyAuditLog) *fastrlp.Value {
+	vv := ar.NewArray()
+	vv.Set(ar.NewUint(entry.BlockNumber))
+	vv.Set(ar.NewBigInt(entry.Amount))
+	vv.Set(ar.NewString(string(entry.Type)))
+	vv.Set(ar.NewUint(entry.Timestamp))
+	vv.Set(ar.NewString(entry.Caller))
+	vv.Set(ar.NewString(entry.Reason))
+
+	if entry.Recipient != nil {
+		vv.Set(ar.NewCopyBytes(entry.Recipient.Bytes()))
+	} else {
+		vv.Set(ar.NewNull())
+	}
+
+	vv.Set(ar.NewCopyBytes(entry.PrevHash.Bytes()))
+
+	return vv
+}
+
+// ReadAuditEntries decodes binary frames from r until EOF
+func ReadAuditEntries(r io.Reader) ([]SupplyAuditLog, error) {
+	entries := make([]SupplyAuditLog, 0)
+	header := make([]byte, auditFrameHeaderSize)
+
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return entries, nil
+			}
+
+			return nil, fmt.Errorf("%w: truncated frame header: %v", ErrInvalidAuditFrame, err)
+		}
+
+		size := binary.BigEndian.Uint32(header)
+		if size > maxAuditFrameSize {
+			return nil, fmt.Errorf("%w: frame of %d bytes exceeds the %d byte limit",
+				ErrInvalidAuditFrame, size, maxAuditFrameSize)
+		}
+
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return nil, fmt.Errorf("%w: truncated frame payload: %v", ErrInvalidAuditFrame, err)
+		}
+
+		entry, err := decodeAuditEntry(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+}
+
+// decodeAuditEntry decodes the RLP payload of a single frame
+func decodeAuditEntry(payload []byte) (SupplyAuditLog, error) {
+	var entry SupplyAuditLog
+
+	p := fastrlp.DefaultParserPool.Get()
+	defer fastrlp.DefaultParserPool.Put(p)
+
+	v, err := p.Parse(payload)
+	if err != nil {
+		return entry, fmt.Errorf("%w: %v", ErrInvalidAuditFrame, err)
+	}
+
+	elems, err := v.GetElems()
+	if err != nil {
+		return entry, fmt.Errorf("%w: %v", ErrInvalidAuditFrame, err)
+	}
+
+	if len(elems) != auditEntryFields && len(elems) != legacyAuditEntryFields {
+		return entry, fmt.Errorf("%w: expected %d fields, got %d", ErrInvalidAuditFrame, auditEntryFields, len(elems))
+	}
+
+	if entry.BlockNumber, err = elems[0].GetUint64(); err != nil {
+		return entry, fmt.Errorf("%w: block number: %v", ErrInvalidAuditFrame, err)
+	}
+
+	entry.Amount = new(big.Int)
+	if err = elems[1].GetBigInt(entry.Amount); err != nil {
+		return entry, fmt.Errorf("%w: amount: %v", ErrInvalidAuditFrame, err)
+	}
+
+	changeType, err := elems[2].GetString()
+	if err != nil {
+		return entry, fmt.Errorf("%w: type: %v", ErrInvalidAuditFrame, err)
+	}
+
+	switch entry.Type = SupplyChangeType(changeType); entry.Type {
+	case ChangeMint, ChangeBurn:
+	default:
+		return entry, fmt.Errorf("%w: %q", ErrUnknownChangeType, changeType)
+	}
+
+	if entry.Timestamp, err = elems[3].GetUint64(); err != nil {
+		return entry, fmt.Errorf("%w: timestamp: %v", ErrInvalidAuditFrame, err)
+	}
+
+	if entry.Caller, err = elems[4].GetString(); err != nil {
+		return entry, fmt.Errorf("%w: caller: %v", ErrInvalidAuditFrame, err)
+	}
+
+	if entry.Reason, err = elems[5].GetString(); err != nil {
+		return entry, fmt.Errorf("%w: reason: %v", ErrInvalidAuditFrame, err)
+	}
+
+	recipient, err := elems[6].Bytes()
+	if err != nil {
+		return entry, fmt.Errorf("%w: recipient: %v", ErrInvalidAuditFrame, err)
+	}
+
+	switch len(recipient) {
+	case 0:
+	case types.AddressLength:
+		addr := types.BytesToAddress(recipient)
+		entry.Recipient = &addr
+	default:
+		return entry, fmt.Errorf("%w: recipient of %d bytes", ErrInvalidAuditFrame, len(recipient))
+	}
+
+	if len(elems) == legacyAuditEntryFields {
+		return entry, nil
+	}
+
+	prevHash, err := elems[7].Bytes()
+	if err != nil {
+		return entry, fmt.Errorf("%w: previous hash: %v", ErrInvalidAuditFrame, err)
+	}
+
+	if len(prevHash) != types.HashLength {
+		return entry, fmt.Errorf("%w: previous hash of %d bytes", ErrInvalidAuditFrame, len(prevHash))
+	}
+
+	entry.PrevHash = types.BytesToHash(prevHash)
+
+	return entry, nil
+}
@@ -0,0 +1,90 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// capReachedHook runs a callback the first time the supply cap is reached. The
+// fired flag is persisted to a file when a path is set, so the callback does not
+// run again after a restart.
+type capReachedHook struct {
+	fn    func(blockNumber uint64)
+	fired bool
+	path  string
+	mutex sync.Mutex
+}
+
+var globalCapReachedHook = &capReachedHook{}
+
+// OnCapReached registers fn to be called once, with the block number, the first
+// time a reward mint reaches the supply cap. Every capped reward mint of the
+// tracker, as well as MintAndNotify and MintBlockReward, notifies it. Registering
+// a new callback replaces the previous one. The callback runs synchronously after
+// the mint, outside the tracker lock, and must not register another callback.
+func OnCapReached(fn func(blockNumber uint64)) {
+	globalCapReachedHook.mutex.Lock()
+	defer globalCapReachedHook.mutex.Unlock()
+
+	globalCapReachedHook.fn = fn
+}
+
+// SetCapReachedFlagPath sets the file persisting that the cap-reached callback
+// already fired, loading the flag if the file exists. An empty path keeps the
+// flag in memory only.
+func SetCapReachedFlagPath(path string) error {
+	fired := false
+
+	if path != "" {
+		_, err := os.Stat(path)
+
+		switch {
+		case err == nil:
+			fired = true
+		case !errors.Is(err, os.ErrNotExist):
+			return fmt.Errorf("failed to load cap reached flag: %w", err)
+		}
+	}
+
+	globalCapReachedHook.mutex.Lock()
+	defer globalCapReachedHook.mutex.Unlock()
+
+	globalCapReachedHook.path = path
+	globalCapReachedHook.fired = fired
+
+	return nil
+}
+
+// notifyCapReached runs the registered callback unless it already fired. Without
+// a callback nothing is recorded, so a callback registered later still runs the
+// next time the cap is hit. The fired flag is persisted once the callback has
+// run. Must not be called while holding the tracker lock.
+func notifyCapReached(blockNumber uint64) {
+	h := globalCapReachedHook
+
+	h.mutex.Lock()
+
+	if h.fired || h.fn == nil {
+		h.mutex.Unlock()
+
+		return
+	}
+
+	h.fired = true
+	fn, path := h.fn, h.path
+
+	h.mutex.Unlock()
+
+	getSupplyLogger().Warn("supply cap reached for the first time", "block", blockNumber)
+
+	fn(blockNumber)
+
+	if path != "" {
+		if err := os.WriteFile(path, []byte(strconv.FormatUint(blockNumber, 10)), 0600); err != nil {
+			getSupplyLogger().Warn("failed to persist cap reached flag", "path", path, "err", err)
+		}
+	}
+}
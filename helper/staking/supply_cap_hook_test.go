@@ -0,0 +1,160 @@
+package staking
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestOnCapReached(t *testing.T) {
+	defaultMax := getMaxSupply()
+	defer func() {
+		OnCapReached(nil)
+
+		if err := SetCapReachedFlagPath(""); err != nil {
+			t.Fatalf("Failed to reset cap reached flag: %v", err)
+		}
+
+		if err := SetMaxSupply(defaultMax); err != nil {
+			t.Fatalf("Failed to restore max supply: %v", err)
+		}
+	}()
+
+	flagPath := filepath.Join(t.TempDir(), "supply_cap_reached")
+	if err := SetCapReachedFlagPath(flagPath); err != nil {
+		t.Fatalf("Failed to set cap reached flag path: %v", err)
+	}
+
+	var fired []uint64
+
+	OnCapReached(func(blockNumber uint64) {
+		fired = append(fired, blockNumber)
+	})
+
+	// Room for one full reward and a partial second one
+	reward := big.NewInt(BlockRewardAmount)
+	if err := SetMaxSupply(new(big.Int).Add(reward, big.NewInt(10))); err != nil {
+		t.Fatalf("Failed to set max supply: %v", err)
+	}
+
+	sst := NewSystemSupplyTracker(big.NewInt(0))
+	owner := types.StringToAddress(testOwnerAddress)
+
+	for block := uint64(1); block <= 4; block++ {
+		if _, err := sst.MintRewardWithCap(newMockTxn(), block, owner); err != nil {
+			t.Fatalf("Block %d: failed to mint: %v", block, err)
+		}
+	}
+
+	if len(fired) != 1 || fired[0] != 2 {
+		t.Fatalf("Expected the callback to fire once at block 2, got %v", fired)
+	}
+
+	// The persisted flag keeps the callback from firing again after a restart
+	if err := SetCapReachedFlagPath(flagPath); err != nil {
+		t.Fatalf("Failed to reload cap reached flag: %v", err)
+	}
+
+	if _, err := NewSystemSupplyTracker(big.NewInt(0)).MintRewardWithCap(newMockTxn(), 5, owner); err != nil {
+		t.Fatalf("Failed to mint: %v", err)
+	}
+
+	if err := MintBlockReward(newMockTxn(), 5, owner); err != nil {
+		t.Fatalf("Failed to mint: %v", err)
+	}
+
+	if len(fired) != 1 {
+		t.Errorf("Expected the callback not to fire again, got %v", fired)
+	}
+
+	// Without the flag file the callback fires again
+	if err := SetCapReachedFlagPath(filepath.Join(t.TempDir(), "supply_cap_reached")); err != nil {
+		t.Fatalf("Failed to set cap reached flag path: %v", err)
+	}
+
+	if err := MintBlockReward(newMockTxn(), 5, owner); err != nil {
+		t.Fatalf("Failed to mint: %v", err)
+	}
+
+	if len(fired) != 2 || fired[1] != 5 {
+		t.Errorf("Expected MintBlockReward to fire the callback at block 5, got %v", fired)
+	}
+}
+
+func TestOnCapReachedFromEveryRewardMint(t *testing.T) {
+	defaultMax := getMaxSupply()
+	defer func() {
+		OnCapReached(nil)
+
+		if err := SetCapReachedFlagPath(""); err != nil {
+			t.Fatalf("Failed to reset cap reached flag: %v", err)
+		}
+
+		if err := SetMaxSupply(defaultMax); err != nil {
+			t.Fatalf("Failed to restore max supply: %v", err)
+		}
+	}()
+
+	flagPath := filepath.Join(t.TempDir(), "supply_cap_reached")
+	if err := SetCapReachedFlagPath(flagPath); err != nil {
+		t.Fatalf("Failed to set cap reached flag path: %v", err)
+	}
+
+	reward := big.NewInt(BlockRewardAmount)
+	if err := SetMaxSupply(new(big.Int).Mul(reward, big.NewInt(2))); err != nil {
+		t.Fatalf("Failed to set max supply: %v", err)
+	}
+
+	owner := types.StringToAddress(testOwnerAddress)
+	sst := NewSystemSupplyTracker(big.NewInt(0))
+
+	// Reaching the cap without a callback leaves the flag unset
+	if _, err := sst.MintBlockRewardRange(newMockTxn(), 1, 3, owner); err != nil {
+		t.Fatalf("Failed to mint range: %v", err)
+	}
+
+	if _, err := os.Stat(flagPath); !os.IsNotExist(err) {
+		t.Fatalf("Expected no persisted flag without a callback, got %v", err)
+	}
+
+	var fired []uint64
+
+	OnCapReached(func(blockNumber uint64) {
+		fired = append(fired, blockNumber)
+	})
+
+	validators := []StakedValidator{{Address: types.StringToAddress("0x1"), Stake: big.NewInt(1)}}
+
+	if _, err := sst.MintBlockRewardToValidators(newMockTxn(), 4, validators, owner); err != nil {
+		t.Fatalf("Failed to mint to validators: %v", err)
+	}
+
+	if len(fired) != 1 || fired[0] != 4 {
+		t.Fatalf("Expected the callback to fire at block 4, got %v", fired)
+	}
+
+	if _, err := os.Stat(flagPath); err != nil {
+		t.Errorf("Expected the flag to be persisted once the callback ran, got %v", err)
+	}
+
+	// The epoch accumulator goes through the same path
+	if err := SetCapReachedFlagPath(filepath.Join(t.TempDir(), "supply_cap_reached")); err != nil {
+		t.Fatalf("Failed to set cap reached flag path: %v", err)
+	}
+
+	accumulator := NewEpochRewardAccumulator(sst)
+	if err := accumulator.Accumulate(5, reward); err != nil {
+		t.Fatalf("Failed to accumulate: %v", err)
+	}
+
+	if _, err := accumulator.FinalizeEpochByStake(newMockTxn(), 1, validators); err != nil {
+		t.Fatalf("Failed to finalize epoch: %v", err)
+	}
+
+	if len(fired) != 2 || fired[1] != 5 {
+		t.Errorf("Expected the epoch mint to fire the callback at block 5, got %v", fired)
+	}
+}
@@ -0,0 +1,71 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var ErrBrokenAuditChain = errors.New("audit log hash chain is broken")
+
+// AuditEntryHash returns the keccak hash of the entry's binary frame payload,
+// which includes its PrevHash, see AppendAuditEntryBinary. The next audit entry
+// links to it through its PrevHash.
+func AuditEntryHash(entry SupplyAuditLog) types.Hash {
+	ar := fastrlp.DefaultArenaPool.Get()
+	defer fastrlp.DefaultArenaPool.Put(ar)
+
+	if entry.Amount == nil {
+		entry.Amount = big.NewInt(0)
+	}
+
+	return types.BytesToHash(keccak.Keccak256Rlp(nil, encodeAuditEntry(ar, entry)))
+}
+
+// relinkFromLocked recomputes the PrevHash of every entry from index i onwards,
+// after the tracker itself changed the log structure (caller must hold the lock).
+// The first entry links to the zero hash.
+func (st *SupplyTracker) relinkFromLocked(i int) {
+	for ; i < len(st.auditLog); i++ {
+		if i == 0 {
+			st.auditLog[i].PrevHash = types.ZeroHash
+		} else {
+			st.auditLog[i].PrevHash = AuditEntryHash(st.auditLog[i-1])
+		}
+	}
+}
+
+// VerifyChain walks the audit log and checks that every entry's PrevHash matches
+// the hash of the entry before it, so any entry inserted, removed or modified
+// outside of the tracker is detected. The first entry must link to the zero hash.
+func (st *SupplyTracker) VerifyChain() error {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	return verifyAuditChain(st.auditLog)
+}
+
+// verifyAuditChain checks the PrevHash links of the entries, see VerifyChain
+func verifyAuditChain(entries []SupplyAuditLog) error {
+	prevHash := types.ZeroHash
+
+	for i, entry := range entries {
+		if entry.PrevHash != prevHash {
+			return fmt.Errorf("%w at entry %d (block %d): expected previous hash %s, got %s",
+				ErrBrokenAuditChain, i, entry.BlockNumber, prevHash, entry.PrevHash)
+		}
+
+		prevHash = AuditEntryHash(entry)
+	}
+
+	return nil
+}
+
+// VerifyChain checks the hash chain of the audit log, see SupplyTracker.VerifyChain
+func (sst *SystemSupplyTracker) VerifyChain() error {
+	return sst.tracker.VerifyChain()
+}
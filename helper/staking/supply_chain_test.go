@@ -0,0 +1,108 @@
+package staking
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestSupplyTrackerVerifyChain(t *testing.T) {
+	tracker := NewSupplyTracker(big.NewInt(1000))
+
+	if err := tracker.VerifyChain(); err != nil {
+		t.Errorf("Expected an empty audit log to verify, got %v", err)
+	}
+
+	for block := uint64(1); block <= 4; block++ {
+		if err := tracker.Mint(big.NewInt(100), block, "consensus_engine"); err != nil {
+			t.Fatalf("Failed to mint: %v", err)
+		}
+	}
+
+	if err := tracker.Burn(big.NewInt(50), 5, "consensus_engine"); err != nil {
+		t.Fatalf("Failed to burn: %v", err)
+	}
+
+	if err := tracker.VerifyChain(); err != nil {
+		t.Fatalf("Expected the audit log to verify, got %v", err)
+	}
+
+	auditLog := tracker.GetAuditLog()
+	if auditLog[0].PrevHash != types.ZeroHash {
+		t.Errorf("Expected the first entry to link to the zero hash, got %s", auditLog[0].PrevHash)
+	}
+
+	if auditLog[1].PrevHash != AuditEntryHash(auditLog[0]) {
+		t.Errorf("Expected the second entry to link to the first")
+	}
+
+	// The chain survives the binary encoding
+	data, err := tracker.MarshalAuditLogBinary()
+	if err != nil {
+		t.Fatalf("Failed to encode the audit log: %v", err)
+	}
+
+	decoded, err := ReadAuditEntries(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Failed to decode the audit log: %v", err)
+	}
+
+	if decoded[4].PrevHash != auditLog[4].PrevHash {
+		t.Errorf("Expected the decoded entry to keep its previous hash")
+	}
+
+	// Structural changes made by the tracker relink the chain
+	tracker.CompactBefore(2)
+
+	if err := tracker.VerifyChain(); err != nil {
+		t.Errorf("Expected the compacted audit log to verify, got %v", err)
+	}
+
+	// Modifying an entry behind the tracker's back breaks the chain
+	tracker.mutex.Lock()
+	tracker.auditLog[1].Amount = big.NewInt(1000)
+	tracker.mutex.Unlock()
+
+	if err := tracker.VerifyChain(); !errors.Is(err, ErrBrokenAuditChain) {
+		t.Errorf("Expected ErrBrokenAuditChain for a modified entry, got %v", err)
+	}
+
+	tracker.mutex.Lock()
+	tracker.auditLog[1].Amount = big.NewInt(100)
+	tracker.mutex.Unlock()
+
+	if err := tracker.VerifyChain(); err != nil {
+		t.Fatalf("Expected the restored audit log to verify, got %v", err)
+	}
+
+	// So does removing one
+	tracker.mutex.Lock()
+	tracker.auditLog = append(tracker.auditLog[:1], tracker.auditLog[2:]...)
+	tracker.mutex.Unlock()
+
+	if err := tracker.VerifyChain(); !errors.Is(err, ErrBrokenAuditChain) {
+		t.Errorf("Expected ErrBrokenAuditChain for a removed entry, got %v", err)
+	}
+}
+
+func TestReconcileGenesisChangeKeepsChain(t *testing.T) {
+	sst := NewSystemSupplyTracker(big.NewInt(1000))
+
+	for block := uint64(1); block <= 3; block++ {
+		if err := sst.tracker.Mint(big.NewInt(10), block, "consensus_engine"); err != nil {
+			t.Fatalf("Failed to mint: %v", err)
+		}
+	}
+
+	// The genesis adjustment is inserted ahead of the existing entries
+	if err := sst.ReconcileGenesisChange(big.NewInt(1000), big.NewInt(900)); err != nil {
+		t.Fatalf("Failed to reconcile genesis change: %v", err)
+	}
+
+	if err := sst.VerifyChain(); err != nil {
+		t.Errorf("Expected the audit log to verify after an insertion, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package staking
+
+import (
+	"fmt"
+	"io"
+)
+
+// debugDumpEntries is the number of most recent audit entries included in a debug dump
+const debugDumpEntries = 10
+
+// DebugDump writes a formatted snapshot of the supply tracker state to w,
+// meant to be attached to support tickets as is
+func (sst *SystemSupplyTracker) DebugDump(w io.Writer) error {
+	st := sst.tracker
+
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	currentSupply := st.getCurrentSupply()
+	maxSupply := getMaxSupply()
+	genesisTotal := getGenesisTotal()
+
+	var lastRewardedBlock uint64
+
+	hasReward := false
+
+	for i := len(st.auditLog) - 1; i >= 0; i-- {
+		if st.auditLog[i].Type == ChangeMint {
+			lastRewardedBlock = st.auditLog[i].BlockNumber
+			hasReward = true
+
+			break
+		}
+	}
+
+	dw := &debugWriter{w: w}
+
+	dw.printf("=== SUPPLY TRACKER DEBUG DUMP ===\n")
+	dw.printf("--- Config ---\n")
+	dw.printf("Max Supply: %s wei (%s AZE)\n", maxSupply.String(), FormatAZE(maxSupply))
+	dw.printf("Block Reward: %d wei\n", BlockRewardAmount)
+	dw.printf("Cap Tolerance: %s wei\n", st.capTolerance.String())
+	dw.printf("Mint Authority: %s\n", st.mintAuthority.String())
+	dw.printf("Deterministic Timestamps: %t\n", st.blockTimestampFn != nil)
+
+	dw.printf("--- Supply ---\n")
+	dw.printf("Initial Supply: %s wei (%s AZE)\n", st.initialSupply.String(), FormatAZE(st.initialSupply))
+	dw.printf("Current Supply: %s wei (%s AZE)\n", currentSupply.String(), FormatAZE(currentSupply))
+	dw.printf("Genesis Total: %s wei (%s AZE)\n", genesisTotal.String(), FormatAZE(genesisTotal))
+
+	dw.printf("--- Audit Log ---\n")
+	dw.printf("Entry Count: %d\n", len(st.auditLog))
+
+	if hasReward {
+		dw.printf("Last Rewarded Block: %d\n", lastRewardedBlock)
+	} else {
+		dw.printf("Last Rewarded Block: none\n")
+	}
+
+	dw.printf("--- Cap Status ---\n")
+	dw.printf("Cap Reached: %t\n", currentSupply.Cmp(maxSupply) >= 0)
+
+	start := len(st.auditLog) - debugDumpEntries
+	if start < 0 {
+		start = 0
+	}
+
+	dw.printf("--- Last %d Audit Entries ---\n", len(st.auditLog)-start)
+
+	for i := start; i < len(st.auditLog); i++ {
+		entry := st.auditLog[i]
+		dw.printf("[%d] block=%d type=%s amount=%s caller=%s timestamp=%d\n",
+			i, entry.BlockNumber, entry.Type, entry.Amount.String(), entry.Caller, entry.Timestamp)
+	}
+
+	dw.printf("=================================\n")
+
+	return dw.err
+}
+
+// debugWriter writes formatted output and remembers the first write error
+type debugWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (dw *debugWriter) printf(format string, args ...interface{}) {
+	if dw.err != nil {
+		return
+	}
+
+	_, dw.err = fmt.Fprintf(dw.w, format, args...)
+}
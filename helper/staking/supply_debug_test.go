@@ -0,0 +1,49 @@
+package staking
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestSystemSupplyTrackerDebugDump(t *testing.T) {
+	sst := NewSystemSupplyTracker(big.NewInt(0))
+	txn := newMockTxn()
+
+	for block := uint64(1); block <= 12; block++ {
+		if _, err := sst.MintRewardWithCap(txn, block, types.StringToAddress(testOwnerAddress)); err != nil {
+			t.Fatalf("Failed to mint: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := sst.DebugDump(&buf); err != nil {
+		t.Fatalf("Failed to dump: %v", err)
+	}
+
+	dump := buf.String()
+	expected := []string{
+		"--- Config ---",
+		"--- Supply ---",
+		"Current Supply: 12000000000000000000 wei (12 AZE)",
+		"Genesis Total:",
+		"Entry Count: 12",
+		"Last Rewarded Block: 12",
+		"Cap Reached: false",
+		"--- Last 10 Audit Entries ---",
+		"[11] block=12 type=mint",
+	}
+
+	for _, section := range expected {
+		if !strings.Contains(dump, section) {
+			t.Errorf("Expected dump to contain %q\n%s", section, dump)
+		}
+	}
+
+	if strings.Contains(dump, "[1] block=2") {
+		t.Error("Expected only the last 10 entries in the dump")
+	}
+}
@@ -0,0 +1,66 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+)
+
+func FuzzMintCapClamping(f *testing.F) {
+	reward := big.NewInt(BlockRewardAmount).Bytes()
+	maxSupply := getMaxSupply()
+
+	seeds := []struct {
+		current, reward, max []byte
+	}{
+		{[]byte{}, reward, maxSupply.Bytes()},
+		{maxSupply.Bytes(), reward, maxSupply.Bytes()},
+		{new(big.Int).Sub(maxSupply, big.NewInt(1)).Bytes(), reward, maxSupply.Bytes()},
+		{new(big.Int).Sub(maxSupply, big.NewInt(BlockRewardAmount)).Bytes(), reward, maxSupply.Bytes()},
+		{new(big.Int).Add(maxSupply, big.NewInt(1)).Bytes(), reward, maxSupply.Bytes()},
+		{[]byte{10}, []byte{}, []byte{10}},
+	}
+
+	for _, seed := range seeds {
+		f.Add(seed.current, seed.reward, seed.max)
+	}
+
+	f.Fuzz(func(t *testing.T, currentBytes, rewardBytes, maxBytes []byte) {
+		t.Parallel()
+
+		current := new(big.Int).SetBytes(currentBytes)
+		reward := new(big.Int).SetBytes(rewardBytes)
+		maxSupply := new(big.Int).SetBytes(maxBytes)
+
+		minted := computeMintableReward(current, reward, maxSupply)
+
+		if minted.Sign() < 0 {
+			t.Fatalf("minted %s is negative", minted)
+		}
+
+		// min(reward, max(cap-current, 0))
+		expected := new(big.Int).Sub(maxSupply, current)
+		if expected.Sign() < 0 {
+			expected.SetInt64(0)
+		}
+
+		if reward.Cmp(expected) < 0 {
+			expected.Set(reward)
+		}
+
+		if minted.Cmp(expected) != 0 {
+			t.Fatalf("current %s, reward %s, cap %s: minted %s, expected %s",
+				current, reward, maxSupply, minted, expected)
+		}
+
+		if current.Cmp(maxSupply) <= 0 {
+			if supply := new(big.Int).Add(current, minted); supply.Cmp(maxSupply) > 0 {
+				t.Fatalf("supply %s exceeds cap %s", supply, maxSupply)
+			}
+		}
+
+		// The result must not alias the caller's reward
+		if minted.Sign() > 0 && minted == reward {
+			t.Fatal("minted aliases the reward")
+		}
+	})
+}
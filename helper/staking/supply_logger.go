@@ -0,0 +1,41 @@
+package staking
+
+import (
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// SupplyLogger receives the supply tracker's diagnostics. hclog.Logger satisfies it.
+type SupplyLogger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+var (
+	supplyLogger     SupplyLogger = hclog.NewNullLogger()
+	supplyLoggerLock sync.RWMutex
+)
+
+// SetSupplyLogger sets the logger used for supply diagnostics. A nil logger
+// discards them, which is the default.
+func SetSupplyLogger(logger SupplyLogger) {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+
+	supplyLoggerLock.Lock()
+	defer supplyLoggerLock.Unlock()
+
+	supplyLogger = logger
+}
+
+// getSupplyLogger returns the configured supply logger
+func getSupplyLogger() SupplyLogger {
+	supplyLoggerLock.RLock()
+	defer supplyLoggerLock.RUnlock()
+
+	return supplyLogger
+}
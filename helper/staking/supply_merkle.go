@@ -0,0 +1,72 @@
+package staking
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	merkle "github.com/0xPolygon/polygon-edge/merkle-tree"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// AuditLogLeaf returns the deterministic merkle leaf encoding of an audit entry:
+// index (8 bytes) | block number (8 bytes) | amount (32 bytes) | type | caller.
+// The index keeps otherwise identical entries distinguishable in the tree.
+func AuditLogLeaf(index int, entry SupplyAuditLog) []byte {
+	leaf := make([]byte, 16, 48+len(entry.Type)+len(entry.Caller))
+	binary.BigEndian.PutUint64(leaf[0:8], uint64(index))
+	binary.BigEndian.PutUint64(leaf[8:16], entry.BlockNumber)
+
+	var amount []byte
+	if entry.Amount != nil {
+		amount = entry.Amount.Bytes()
+	}
+
+	leaf = append(leaf, common.PadLeftOrTrim(amount, 32)...)
+	leaf = append(leaf, entry.Type...)
+	leaf = append(leaf, entry.Caller...)
+
+	return leaf
+}
+
+// AuditLogMerkleRoot returns the keccak merkle root over the audit log,
+// or the zero hash if the log is empty
+func (st *SupplyTracker) AuditLogMerkleRoot() types.Hash {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	tree, err := st.auditLogMerkleTree()
+	if err != nil {
+		return types.ZeroHash
+	}
+
+	return tree.Hash()
+}
+
+// ProveEntry returns the merkle inclusion proof for the audit entry at the given index.
+// The proof can be checked with merkle.VerifyProof against AuditLogLeaf and the root.
+func (st *SupplyTracker) ProveEntry(index int) ([]types.Hash, error) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	if index < 0 || index >= len(st.auditLog) {
+		return nil, fmt.Errorf("audit log index %d out of range [0, %d)", index, len(st.auditLog))
+	}
+
+	tree, err := st.auditLogMerkleTree()
+	if err != nil {
+		return nil, err
+	}
+
+	return tree.GenerateProof(AuditLogLeaf(index, st.auditLog[index]))
+}
+
+// auditLogMerkleTree builds the merkle tree over the audit log (caller must hold the lock)
+func (st *SupplyTracker) auditLogMerkleTree() (*merkle.MerkleTree, error) {
+	leaves := make([][]byte, len(st.auditLog))
+	for i, entry := range st.auditLog {
+		leaves[i] = AuditLogLeaf(i, entry)
+	}
+
+	return merkle.NewMerkleTree(leaves)
+}
@@ -0,0 +1,58 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	merkle "github.com/0xPolygon/polygon-edge/merkle-tree"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestSupplyTrackerAuditLogMerkleProof(t *testing.T) {
+	tracker := NewSupplyTracker(big.NewInt(1000))
+
+	if tracker.AuditLogMerkleRoot() != types.ZeroHash {
+		t.Error("Expected zero root for an empty audit log")
+	}
+
+	for block := uint64(1); block <= 5; block++ {
+		if err := tracker.Mint(big.NewInt(100), block, "consensus_engine"); err != nil {
+			t.Fatalf("Failed to mint: %v", err)
+		}
+	}
+
+	if err := tracker.Burn(big.NewInt(50), 6, "consensus_engine"); err != nil {
+		t.Fatalf("Failed to burn: %v", err)
+	}
+
+	root := tracker.AuditLogMerkleRoot()
+	auditLog := tracker.GetAuditLog()
+
+	for i, entry := range auditLog {
+		proof, err := tracker.ProveEntry(i)
+		if err != nil {
+			t.Fatalf("Failed to prove entry %d: %v", i, err)
+		}
+
+		if err := merkle.VerifyProof(uint64(i), AuditLogLeaf(i, entry), proof, root); err != nil {
+			t.Errorf("Failed to verify entry %d: %v", i, err)
+		}
+	}
+
+	// A tampered amount does not verify against the root
+	proof, err := tracker.ProveEntry(5)
+	if err != nil {
+		t.Fatalf("Failed to prove entry: %v", err)
+	}
+
+	tampered := auditLog[5]
+	tampered.Amount = big.NewInt(1)
+
+	if err := merkle.VerifyProof(5, AuditLogLeaf(5, tampered), proof, root); err == nil {
+		t.Error("Expected tampered entry to fail verification")
+	}
+
+	if _, err := tracker.ProveEntry(6); err == nil {
+		t.Error("Expected out of range index to fail")
+	}
+}
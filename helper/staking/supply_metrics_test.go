@@ -0,0 +1,54 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRegisterMetricsIdempotent(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	RegisterMetrics(registry)
+	RegisterMetrics(registry)
+
+	InitializeSupplyTracker(big.NewInt(0))
+	defer InitializeSupplyTracker(big.NewInt(0))
+
+	tracker := GetGlobalSupplyTracker().tracker
+	if err := tracker.Mint(big.NewInt(3000000000000000000), 1, "consensus_engine"); err != nil {
+		t.Fatalf("Failed to mint: %v", err)
+	}
+
+	if err := tracker.Burn(big.NewInt(1000000000000000000), 2, "consensus_engine"); err != nil {
+		t.Fatalf("Failed to burn: %v", err)
+	}
+
+	if got := testutil.ToFloat64(totalSupplyGauge); got != 2 {
+		t.Errorf("Expected total supply gauge 2 AZE, got %f", got)
+	}
+
+	if got := testutil.ToFloat64(totalMintedGauge); got != 3 {
+		t.Errorf("Expected minted gauge 3 AZE, got %f", got)
+	}
+
+	if got := testutil.ToFloat64(totalBurnedGauge); got != 1 {
+		t.Errorf("Expected burned gauge 1 AZE, got %f", got)
+	}
+
+	if got := testutil.ToFloat64(maxSupplyGauge); got != 1e9 {
+		t.Errorf("Expected max supply gauge 1e9 AZE, got %f", got)
+	}
+
+	// Trackers other than the global one leave the gauges alone
+	other := NewSupplyTracker(big.NewInt(0))
+	if err := other.Mint(big.NewInt(5000000000000000000), 1, "consensus_engine"); err != nil {
+		t.Fatalf("Failed to mint: %v", err)
+	}
+
+	if got := testutil.ToFloat64(totalSupplyGauge); got != 2 {
+		t.Errorf("Expected total supply gauge to stay at 2 AZE, got %f", got)
+	}
+}
@@ -0,0 +1,29 @@
+package staking
+
+import (
+	"io"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// SupplyReader is the read-only view of a supply tracker, for consumers such as
+// report generators that must never mint or burn
+type SupplyReader interface {
+	GetCurrentSupply() *big.Int
+	GetAuditLog() []SupplyAuditLog
+	GetAuditLogPaginated(offset, limit int) ([]SupplyAuditLog, int)
+	GetLastAuditEntry() (SupplyAuditLog, bool)
+	AuditLogLen() int
+	Stats() SupplyStats
+	RemainingMintableSupply() *big.Int
+	RemainingMintableSupplyAZE() string
+	SupplyPercentOfCap() float64
+	GetRewardsByRecipient(addr types.Address) *big.Int
+	ValidatorRewardHistory(v types.Address, fromBlock, toBlock uint64) []SupplyAuditLog
+	ReconcileSupply(blockNumber uint64) error
+	ExportSnapshot() SupplySnapshot
+	DebugDump(w io.Writer) error
+}
+
+var _ SupplyReader = (*SystemSupplyTracker)(nil)
@@ -0,0 +1,159 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var ErrInvalidSnapshot = errors.New("invalid supply snapshot")
+
+// SupplySnapshot captures the supply state needed to resume tracking without
+// replaying the full audit log, e.g. when a node syncs from a state snapshot
+type SupplySnapshot struct {
+	// InitialSupply is the supply the tracker was originally started with
+	InitialSupply *big.Int `json:"initialSupply"`
+	// CachedSupply is the total supply at LastBlock
+	CachedSupply *big.Int `json:"cachedSupply"`
+	// LastBlock is the last block with a recorded supply change
+	LastBlock uint64 `json:"lastBlock"`
+	// MintedByCaller is the total minted per caller up to LastBlock, so mint
+	// quotas keep counting across snapshots
+	MintedByCaller map[string]*big.Int `json:"mintedByCaller,omitempty"`
+}
+
+// ExportSnapshot returns the current supply state as a snapshot
+func (sst *SystemSupplyTracker) ExportSnapshot() SupplySnapshot {
+	st := sst.tracker
+
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	initialSupply := st.initialSupply
+	if st.snapshot != nil {
+		initialSupply = st.snapshot.InitialSupply
+	}
+
+	lastBlock, _ := st.lastBlockLocked()
+
+	return SupplySnapshot{
+		InitialSupply:  new(big.Int).Set(initialSupply),
+		CachedSupply:   st.getCurrentSupply(),
+		LastBlock:      lastBlock,
+		MintedByCaller: copyMintedByCaller(st.mintedByCaller),
+	}
+}
+
+// ImportSnapshot resets the tracker to the snapshot state: the audit log is
+// cleared, the total supply equals the snapshot's cached supply and, with
+// strict block ordering, new changes must come after the snapshot's last block
+func (sst *SystemSupplyTracker) ImportSnapshot(s SupplySnapshot) error {
+	if s.InitialSupply == nil || s.InitialSupply.Sign() < 0 {
+		return fmt.Errorf("%w: initial supply must be non-negative", ErrInvalidSnapshot)
+	}
+
+	if s.CachedSupply == nil || s.CachedSupply.Sign() < 0 {
+		return fmt.Errorf("%w: cached supply must be non-negative", ErrInvalidSnapshot)
+	}
+
+	if maxSupply := getMaxSupply(); s.CachedSupply.Cmp(maxSupply) > 0 {
+		return fmt.Errorf("%w: cached supply %s wei exceeds max supply %s wei",
+			ErrInvalidSnapshot, s.CachedSupply.String(), maxSupply.String())
+	}
+
+	st := sst.tracker
+
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.snapshot = &SupplySnapshot{
+		InitialSupply:  new(big.Int).Set(s.InitialSupply),
+		CachedSupply:   new(big.Int).Set(s.CachedSupply),
+		LastBlock:      s.LastBlock,
+		MintedByCaller: copyMintedByCaller(s.MintedByCaller),
+	}
+	st.initialSupply = new(big.Int).Set(s.CachedSupply)
+	st.auditLog = make([]SupplyAuditLog, 0)
+	st.resetTotalsLocked()
+	st.updateMetrics()
+
+	getSupplyLogger().Debug("imported supply snapshot", "block", s.LastBlock, "supply", s.CachedSupply.String())
+
+	return nil
+}
+
+// CompactBefore folds every audit entry below blockNumber into the initial supply
+// and drops it, bounding the memory used by the audit log. The total supply is
+// unchanged; minted and burned totals afterwards only cover the retained entries.
+// Block hashes rewarded below blockNumber are forgotten, so they are no longer
+// protected against being minted twice.
+func (st *SupplyTracker) CompactBefore(blockNumber uint64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	folded := new(big.Int).Set(st.initialSupply)
+	kept := make([]SupplyAuditLog, 0, len(st.auditLog))
+	compactedMinted := make(map[string]*big.Int)
+
+	var (
+		lastCompacted uint64
+		compacted     bool
+	)
+
+	for _, change := range st.auditLog {
+		if change.BlockNumber >= blockNumber {
+			kept = append(kept, change)
+
+			continue
+		}
+
+		if change.Type == ChangeMint {
+			folded.Add(folded, change.Amount)
+			addMintedByCaller(compactedMinted, change.Caller, change.Amount)
+		} else if change.Type == ChangeBurn {
+			folded.Sub(folded, change.Amount)
+		}
+
+		if change.BlockNumber > lastCompacted {
+			lastCompacted = change.BlockNumber
+		}
+
+		compacted = true
+	}
+
+	if !compacted {
+		return
+	}
+
+	// Forget the rewarded block hashes of the compacted range along with its entries
+	for hash, minted := range st.mintedBlocks {
+		if minted < blockNumber {
+			delete(st.mintedBlocks, hash)
+		}
+	}
+
+	// Keep the original initial supply and the last folded block around for
+	// snapshots and strict block ordering
+	if st.snapshot == nil {
+		st.snapshot = &SupplySnapshot{InitialSupply: new(big.Int).Set(st.initialSupply)}
+	}
+
+	if lastCompacted > st.snapshot.LastBlock {
+		st.snapshot.LastBlock = lastCompacted
+	}
+
+	if st.snapshot.MintedByCaller == nil {
+		st.snapshot.MintedByCaller = make(map[string]*big.Int)
+	}
+
+	for caller, amount := range compactedMinted {
+		addMintedByCaller(st.snapshot.MintedByCaller, caller, amount)
+	}
+
+	st.snapshot.CachedSupply = new(big.Int).Set(folded)
+	st.initialSupply = folded
+	st.auditLog = kept
+	st.relinkFromLocked(0)
+	st.resetTotalsLocked()
+	st.updateMetrics()
+}
@@ -0,0 +1,107 @@
+package staking
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestSupplySnapshotExportImport(t *testing.T) {
+	source := NewSystemSupplyTracker(big.NewInt(1000))
+
+	for block := uint64(1); block <= 3; block++ {
+		if err := source.MintBlockReward(big.NewInt(10), block); err != nil {
+			t.Fatalf("Failed to mint: %v", err)
+		}
+	}
+
+	snapshot := source.ExportSnapshot()
+	if snapshot.InitialSupply.Cmp(big.NewInt(1000)) != 0 ||
+		snapshot.CachedSupply.Cmp(big.NewInt(1030)) != 0 ||
+		snapshot.LastBlock != 3 {
+		t.Fatalf("Unexpected snapshot: %+v", snapshot)
+	}
+
+	target := NewSystemSupplyTracker(big.NewInt(0))
+	target.tracker.SetStrictBlockOrdering(true)
+
+	if err := target.ImportSnapshot(snapshot); err != nil {
+		t.Fatalf("Failed to import snapshot: %v", err)
+	}
+
+	if got := target.GetCurrentSupply(); got.Cmp(big.NewInt(1030)) != 0 {
+		t.Errorf("Expected supply 1030 after import, got %s", got.String())
+	}
+
+	if err := target.MintBlockReward(big.NewInt(10), 3); !errors.Is(err, ErrNonMonotonicBlock) {
+		t.Errorf("Expected mint at the snapshot block to be rejected, got %v", err)
+	}
+
+	if err := target.MintBlockReward(big.NewInt(10), 4); err != nil {
+		t.Fatalf("Failed to mint after snapshot: %v", err)
+	}
+
+	// Re-exporting keeps the original initial supply
+	reexported := target.ExportSnapshot()
+	if reexported.InitialSupply.Cmp(big.NewInt(1000)) != 0 ||
+		reexported.CachedSupply.Cmp(big.NewInt(1040)) != 0 ||
+		reexported.LastBlock != 4 {
+		t.Errorf("Unexpected re-exported snapshot: %+v", reexported)
+	}
+}
+
+func TestSupplySnapshotImportRejectsAboveMax(t *testing.T) {
+	sst := NewSystemSupplyTracker(big.NewInt(0))
+
+	snapshot := SupplySnapshot{
+		InitialSupply: big.NewInt(0),
+		CachedSupply:  new(big.Int).Add(getMaxSupply(), big.NewInt(1)),
+		LastBlock:     10,
+	}
+
+	if err := sst.ImportSnapshot(snapshot); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Errorf("Expected ErrInvalidSnapshot, got %v", err)
+	}
+}
+
+func TestSupplyTrackerCompactBefore(t *testing.T) {
+	sst := NewSystemSupplyTracker(big.NewInt(1000))
+	tracker := sst.tracker
+
+	for block := uint64(1); block <= 6; block++ {
+		if err := tracker.Mint(big.NewInt(10), block, "consensus_engine"); err != nil {
+			t.Fatalf("Failed to mint: %v", err)
+		}
+
+		if block%2 == 0 {
+			if err := tracker.Burn(big.NewInt(3), block, "consensus_engine"); err != nil {
+				t.Fatalf("Failed to burn: %v", err)
+			}
+		}
+	}
+
+	before := tracker.GetTotalSupply()
+
+	tracker.CompactBefore(4)
+
+	if after := tracker.GetTotalSupply(); after.Cmp(before) != 0 {
+		t.Errorf("Expected supply %s after compaction, got %s", before.String(), after.String())
+	}
+
+	log := tracker.GetAuditLog()
+	if len(log) != 5 || log[0].BlockNumber != 4 {
+		t.Errorf("Expected entries from block 4 onwards to remain, got %+v", log)
+	}
+
+	// Compacting everything keeps the supply, the original initial supply and the last block
+	tracker.CompactBefore(100)
+
+	if after := tracker.GetTotalSupply(); after.Cmp(before) != 0 {
+		t.Errorf("Expected supply %s after full compaction, got %s", before.String(), after.String())
+	}
+
+	snapshot := sst.ExportSnapshot()
+	if snapshot.InitialSupply.Cmp(big.NewInt(1000)) != 0 || snapshot.LastBlock != 6 {
+		t.Errorf("Unexpected snapshot after compaction: %+v", snapshot)
+	}
+}
@@ -0,0 +1,71 @@
+package staking
+
+import (
+	"math/big"
+	"sync"
+)
+
+// subscriberBufferSize is the number of entries a subscription buffers
+// before new entries are dropped for that subscriber
+const subscriberBufferSize = 64
+
+// Subscribe returns a channel receiving a copy of every audit entry recorded
+// after the call, together with a function that ends the subscription.
+//
+// Entries are published while the tracker holds its write lock, so delivery
+// never blocks: when a subscriber's buffer is full the entry is dropped for that
+// subscriber only. Unsubscribing closes the channel and is safe to call twice.
+func (st *SupplyTracker) Subscribe() (<-chan SupplyAuditLog, func()) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	if st.subscribers == nil {
+		st.subscribers = make(map[uint64]chan SupplyAuditLog)
+	}
+
+	id := st.nextSubscriberID
+	st.nextSubscriberID++
+
+	ch := make(chan SupplyAuditLog, subscriberBufferSize)
+	st.subscribers[id] = ch
+
+	var once sync.Once
+
+	unsubscribe := func() {
+		once.Do(func() {
+			st.mutex.Lock()
+			defer st.mutex.Unlock()
+
+			delete(st.subscribers, id)
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// publishLocked delivers a copy of the entry to every subscriber without
+// blocking (caller must hold the lock)
+func (st *SupplyTracker) publishLocked(entry SupplyAuditLog) {
+	for _, ch := range st.subscribers {
+		select {
+		case ch <- copyAuditEntry(entry):
+		default:
+			// Subscriber is not keeping up, drop the entry for it
+		}
+	}
+}
+
+// copyAuditEntry returns a deep copy of the entry
+func copyAuditEntry(entry SupplyAuditLog) SupplyAuditLog {
+	if entry.Amount != nil {
+		entry.Amount = new(big.Int).Set(entry.Amount)
+	}
+
+	if entry.Recipient != nil {
+		recipient := *entry.Recipient
+		entry.Recipient = &recipient
+	}
+
+	return entry
+}
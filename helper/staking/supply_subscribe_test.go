@@ -0,0 +1,70 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSupplyTrackerSubscribe(t *testing.T) {
+	tracker := NewSupplyTracker(big.NewInt(1000))
+
+	entries, unsubscribe := tracker.Subscribe()
+
+	if err := tracker.Mint(big.NewInt(10), 1, "consensus_engine"); err != nil {
+		t.Fatalf("Failed to mint: %v", err)
+	}
+
+	if err := tracker.Burn(big.NewInt(5), 2, "consensus_engine"); err != nil {
+		t.Fatalf("Failed to burn: %v", err)
+	}
+
+	mint := <-entries
+	if mint.Type != ChangeMint || mint.Amount.Cmp(big.NewInt(10)) != 0 || mint.BlockNumber != 1 {
+		t.Errorf("Unexpected mint entry: %+v", mint)
+	}
+
+	burn := <-entries
+	if burn.Type != ChangeBurn || burn.Amount.Cmp(big.NewInt(5)) != 0 || burn.BlockNumber != 2 {
+		t.Errorf("Unexpected burn entry: %+v", burn)
+	}
+
+	// Published entries are copies
+	mint.Amount.SetInt64(999)
+
+	if got := tracker.GetTotalSupply(); got.Cmp(big.NewInt(1005)) != 0 {
+		t.Errorf("Expected supply 1005, got %s", got.String())
+	}
+
+	unsubscribe()
+	unsubscribe()
+
+	if _, ok := <-entries; ok {
+		t.Error("Expected channel to be closed after unsubscribe")
+	}
+
+	// Recording after unsubscribing must not panic or block
+	if err := tracker.Mint(big.NewInt(1), 3, "consensus_engine"); err != nil {
+		t.Fatalf("Failed to mint: %v", err)
+	}
+}
+
+func TestSupplyTrackerSubscribeDropsWhenFull(t *testing.T) {
+	tracker := NewSupplyTracker(big.NewInt(0))
+
+	entries, unsubscribe := tracker.Subscribe()
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBufferSize+10; i++ {
+		if err := tracker.Mint(big.NewInt(1), uint64(i), "consensus_engine"); err != nil {
+			t.Fatalf("Failed to mint: %v", err)
+		}
+	}
+
+	if len(entries) != subscriberBufferSize {
+		t.Errorf("Expected %d buffered entries, got %d", subscriberBufferSize, len(entries))
+	}
+
+	if got := tracker.GetTotalSupply(); got.Cmp(big.NewInt(subscriberBufferSize+10)) != 0 {
+		t.Errorf("Expected every mint to be recorded, got supply %s", got.String())
+	}
+}
@@ -1,11 +1,17 @@
 package staking
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unsafe"
 
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -18,256 +24,2329 @@ const (
 
 	// Maximum supply: 1 billion AZE
 	MaxSupplyAmount = "1000000000000000000000000000" // 1 billion AZE in wei
+
+	// Legacy caller identifiers accepted for supply changes
+	systemCallerAddress   = "0x0000000000000000000000000000000000000000" // System address
+	consensusEngineCaller = "consensus_engine"                           // System identifier
+
+	// Number of audit entries processed between context cancellation checks
+	ctxCheckInterval = 1024
+)
+
+var (
+	// maxSupplyWei is the configured maximum supply, defaulting to MaxSupplyAmount
+	maxSupplyWei, _ = new(big.Int).SetString(MaxSupplyAmount, 10)
+	// softCapWei is the supply above which mints log a warning, nil when it
+	// equals the max supply. Guarded by maxSupplyLock.
+	softCapWei    *big.Int
+	maxSupplyLock sync.RWMutex
+)
+
+var (
+	// consensusCallers is the allow-list of identifiers accepted as the consensus engine
+	consensusCallers     = defaultConsensusCallers()
+	consensusCallersLock sync.RWMutex
 )
 
 var (
-	ErrSupplyCapExceeded  = errors.New("supply cap exceeded")
-	ErrUnauthorizedMint   = errors.New("unauthorized mint operation")
-	ErrInvalidAmount      = errors.New("invalid amount")
-	ErrInsufficientSupply = errors.New("insufficient supply to burn")
+	// mintingEnabled is the governance kill switch for reward minting
+	mintingEnabled = true
+	mintingLock    sync.RWMutex
+)
+
+var (
+	// TokenDecimals is the number of decimals used to render wei amounts as AZE in logs
+	TokenDecimals uint8 = 18
+	// Guards TokenDecimals
+	tokenDecimalsLock sync.RWMutex
+)
+
+var (
+	ErrSupplyCapExceeded    = errors.New("supply cap exceeded")
+	ErrUnauthorizedMint     = errors.New("unauthorized mint operation")
+	ErrUnauthorizedBurn     = errors.New("unauthorized burn operation")
+	ErrInvalidAmount        = errors.New("invalid amount")
+	ErrInsufficientSupply   = errors.New("insufficient supply to burn")
+	ErrInsufficientStake    = errors.New("insufficient validator balance to slash")
+	ErrInsufficientBalance  = errors.New("insufficient account balance to burn")
+	ErrUnknownChangeType    = errors.New("unknown supply change type")
+	ErrInvalidReplay        = errors.New("invalid replay entries")
+	ErrInvalidMaxSupply     = errors.New("invalid max supply")
+	ErrSupplyMismatch       = errors.New("supply mismatch between audit log and block formula")
+	ErrNonMonotonicBlock    = errors.New("block number is not above the last recorded block")
+	ErrMintingPaused        = errors.New("reward minting is paused")
+	ErrInvalidBlockRange    = errors.New("invalid block range")
+	ErrGenesisNotLoaded     = errors.New("genesis allocation not loaded")
+	ErrMintRateExceeded     = errors.New("mint exceeds per-block limit")
+	ErrMintNotifyFailed     = errors.New("reward recipient contract call failed")
+	ErrZeroAddressRecipient = errors.New("fee recipient is the zero address")
+	ErrSupplyFrozen         = errors.New("supply tracker is frozen")
+	ErrQuotaExceeded        = errors.New("mint exceeds caller quota")
+	ErrNoRewardRecipients   = errors.New("no reward recipients")
+)
+
+// SupplyError attaches the block and amount of a rejected supply change to the
+// underlying sentinel error, which stays matchable through errors.Is
+type SupplyError struct {
+	Err         error
+	BlockNumber uint64
+	// Amount is the attempted amount in wei, nil when not known
+	Amount *big.Int
+}
+
+// newSupplyError wraps err with the block and attempted amount
+func newSupplyError(err error, blockNumber uint64, amount *big.Int) *SupplyError {
+	supplyErr := &SupplyError{Err: err, BlockNumber: blockNumber}
+	if amount != nil {
+		supplyErr.Amount = new(big.Int).Set(amount)
+	}
+
+	return supplyErr
+}
+
+func (e *SupplyError) Error() string {
+	if e.Amount == nil {
+		return fmt.Sprintf("%v (block %d)", e.Err, e.BlockNumber)
+	}
+
+	return fmt.Sprintf("%v (block %d, amount %s wei)", e.Err, e.BlockNumber, e.Amount.String())
+}
+
+func (e *SupplyError) Unwrap() error {
+	return e.Err
+}
+
+// SupplyChangeType identifies the direction of a supply change
+type SupplyChangeType string
+
+const (
+	ChangeMint SupplyChangeType = "mint"
+	ChangeBurn SupplyChangeType = "burn"
 )
 
+// UnmarshalJSON parses a supply change type, rejecting unknown values
+// so that corrupt persisted logs are caught on load
+func (t *SupplyChangeType) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	switch SupplyChangeType(raw) {
+	case ChangeMint, ChangeBurn:
+		*t = SupplyChangeType(raw)
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownChangeType, raw)
+	}
+}
+
 // SupplyAuditLog represents an immutable record of supply changes
 type SupplyAuditLog struct {
-	BlockNumber uint64   `json:"blockNumber"`
-	Amount      *big.Int `json:"amount"`
-	Type        string   `json:"type"` // "mint" or "burn"
-	Timestamp   uint64   `json:"timestamp"`
-	Caller      string   `json:"caller"`
+	BlockNumber uint64           `json:"blockNumber"`
+	Amount      *big.Int         `json:"amount"`
+	Type        SupplyChangeType `json:"type"`
+	Timestamp   uint64           `json:"timestamp"`
+	Caller      string           `json:"caller"`
+	Reason      string           `json:"reason,omitempty"`
+	Recipient   *types.Address   `json:"recipient,omitempty"`
+	// PrevHash is the AuditEntryHash of the previous entry, see VerifyChain
+	PrevHash types.Hash `json:"prevHash"`
+}
+
+// MarshalJSON encodes the entry with Amount as a decimal string, so amounts above
+// 2^53 survive clients that parse JSON numbers as doubles
+func (e SupplyAuditLog) MarshalJSON() ([]byte, error) {
+	type alias SupplyAuditLog
+
+	var amount *string
+
+	if e.Amount != nil {
+		decimal := e.Amount.String()
+		amount = &decimal
+	}
+
+	return json.Marshal(&struct {
+		alias
+		Amount *string `json:"amount"`
+	}{
+		alias:  alias(e),
+		Amount: amount,
+	})
+}
+
+// UnmarshalJSON decodes an entry whose Amount is a decimal or 0x-prefixed hex
+// string, or a bare JSON number as written by older versions
+func (e *SupplyAuditLog) UnmarshalJSON(data []byte) error {
+	type alias SupplyAuditLog
+
+	aux := &struct {
+		*alias
+		Amount json.RawMessage `json:"amount"`
+	}{
+		alias: (*alias)(e),
+	}
+
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+
+	e.Amount = nil
+
+	raw := string(aux.Amount)
+	if raw == "" || raw == "null" {
+		return nil
+	}
+
+	base := 10
+	if unquoted, err := strconv.Unquote(raw); err == nil {
+		raw, base = unquoted, 0
+	}
+
+	amount, ok := new(big.Int).SetString(raw, base)
+	if !ok {
+		return fmt.Errorf("%w: cannot parse amount %s", ErrInvalidAmount, aux.Amount)
+	}
+
+	e.Amount = amount
+
+	return nil
 }
 
 // SupplyTracker manages secure supply tracking
 type SupplyTracker struct {
-	initialSupply *big.Int
-	auditLog      []SupplyAuditLog
-	mutex         sync.RWMutex
+	initialSupply    *big.Int
+	auditLog         []SupplyAuditLog
+	blockTimestampFn func(block uint64) uint64
+	clock            Clock
+	mintAuthority    types.Address
+	burnAuthority    types.Address
+	strictBlockOrder bool
+	snapshot         *SupplySnapshot
+	subscribers      map[uint64]chan SupplyAuditLog
+	nextSubscriberID uint64
+	capTolerance     *big.Int
+	maxMintPerBlock  *big.Int
+	mintedBlocks     map[types.Hash]uint64
+	frozen           bool
+	mintQuotas       map[string]*big.Int
+	// Running totals of the audit log amounts per change type
+	minted *big.Int
+	burned *big.Int
+	// Running total minted per caller, including entries folded into the snapshot
+	mintedByCaller map[string]*big.Int
+	// Reward mints reserved per block while their recipient is called outside the
+	// lock, see MintAndNotify. They count as minted until committed or released.
+	reserved map[uint64]*big.Int
+	// Whether the tracker publishes the supply gauges, only for the global tracker
+	publishMetrics atomic.Bool
+	mutex          sync.RWMutex
 }
 
 // NewSupplyTracker creates a new supply tracker
 func NewSupplyTracker(initialSupply *big.Int) *SupplyTracker {
 	return &SupplyTracker{
-		initialSupply: initialSupply,
-		auditLog:      make([]SupplyAuditLog, 0),
+		initialSupply:  initialSupply,
+		auditLog:       make([]SupplyAuditLog, 0),
+		mintAuthority:  types.ZeroAddress, // System address
+		burnAuthority:  types.ZeroAddress, // System address
+		capTolerance:   big.NewInt(0),
+		clock:          realClock{},
+		minted:         big.NewInt(0),
+		burned:         big.NewInt(0),
+		mintedByCaller: make(map[string]*big.Int),
 	}
 }
 
-// GetTotalSupply calculates total supply from initial supply + all changes
-func (st *SupplyTracker) GetTotalSupply() *big.Int {
+// SetBlockTimestampFunc sets the function used to derive audit entry timestamps
+// from the block number, so that every node records identical timestamps.
+// Passing nil restores wall-clock timestamps.
+func (st *SupplyTracker) SetBlockTimestampFunc(fn func(block uint64) uint64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.blockTimestampFn = fn
+}
+
+// SetClock sets the clock used for audit entry timestamps when no block timestamp
+// function is set. Passing nil restores the wall clock.
+func (st *SupplyTracker) SetClock(clock Clock) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	if clock == nil {
+		clock = realClock{}
+	}
+
+	st.clock = clock
+}
+
+// GetInitialSupply returns a copy of the supply the audit log is applied on top of
+func (st *SupplyTracker) GetInitialSupply() *big.Int {
 	st.mutex.RLock()
 	defer st.mutex.RUnlock()
 
-	total := new(big.Int).Set(st.initialSupply)
-	for _, change := range st.auditLog {
-		if change.Type == "mint" {
-			total.Add(total, change.Amount)
-		} else if change.Type == "burn" {
-			total.Sub(total, change.Amount)
-		}
-	}
-	return total
+	return new(big.Int).Set(st.initialSupply)
 }
 
-// Mint securely mints new tokens (only callable from consensus engine)
-func (st *SupplyTracker) Mint(amount *big.Int, blockNumber uint64, caller string) error {
-	if amount == nil || amount.Cmp(big.NewInt(0)) <= 0 {
-		return ErrInvalidAmount
+// SetInitialSupply replaces the supply the audit log is applied on top of. The
+// value is rejected, leaving the tracker untouched, when applying the existing
+// audit log to it would yield a negative current supply (ErrInvalidAmount) or
+// one above the max supply (ErrSupplyCapExceeded).
+func (st *SupplyTracker) SetInitialSupply(supply *big.Int) error {
+	if supply == nil || supply.Sign() < 0 {
+		return fmt.Errorf("%w: initial supply must not be negative", ErrInvalidAmount)
 	}
 
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
 
-	// Validate caller is consensus engine
-	if !isConsensusEngine(caller) {
-		return ErrUnauthorizedMint
-	}
+	current := new(big.Int).Add(supply, st.sumByType(ChangeMint))
+	current.Sub(current, st.sumByType(ChangeBurn))
 
-	// The cap check is now handled in MintBlockReward, so we only log here.
-	// This prevents a double-check that was causing the partial reward to be rejected.
-	currentSupply := st.getCurrentSupply()
-	maxSupply := getMaxSupply()
-	newSupply := new(big.Int).Add(currentSupply, amount)
+	if current.Sign() < 0 {
+		return fmt.Errorf("%w: initial supply %s wei gives a negative current supply of %s wei",
+			ErrInvalidAmount, supply.String(), current.String())
+	}
 
-	if newSupply.Cmp(maxSupply) > 0 {
-		// We still check here to be absolutely safe, but the primary logic
-		// in MintBlockReward should prevent this from being reached with an invalid amount.
-		// If it is reached, we return the error to halt the process.
-		return ErrSupplyCapExceeded
+	if maxSupply := getMaxSupply(); current.Cmp(maxSupply) > 0 {
+		return fmt.Errorf("%w: initial supply %s wei gives a current supply of %s wei above the max supply of %s wei",
+			ErrSupplyCapExceeded, supply.String(), current.String(), maxSupply.String())
 	}
 
-	// Log the mint operation
-	st.auditLog = append(st.auditLog, SupplyAuditLog{
-		BlockNumber: blockNumber,
-		Amount:      amount,
-		Type:        "mint",
-		Timestamp:   uint64(time.Now().Unix()),
-		Caller:      caller,
-	})
+	st.initialSupply = new(big.Int).Set(supply)
+	st.updateMetrics()
 
 	return nil
 }
 
-// Burn securely burns tokens (only callable from consensus engine)
-func (st *SupplyTracker) Burn(amount *big.Int, blockNumber uint64, caller string) error {
-	if amount == nil || amount.Cmp(big.NewInt(0)) <= 0 {
-		return ErrInvalidAmount
+// entryTimestamp returns the timestamp for a new audit entry (caller must hold the lock)
+func (st *SupplyTracker) entryTimestamp(blockNumber uint64) uint64 {
+	if st.blockTimestampFn != nil {
+		return st.blockTimestampFn(blockNumber)
 	}
 
-	st.mutex.Lock()
-	defer st.mutex.Unlock()
-
-	// Validate caller is consensus engine
-	if !isConsensusEngine(caller) {
-		return ErrUnauthorizedMint
+	if st.clock == nil {
+		return uint64(time.Now().Unix())
 	}
 
-	// Check sufficient supply
-	currentSupply := st.getCurrentSupply()
-	if currentSupply.Cmp(amount) < 0 {
-		return ErrInsufficientSupply
-	}
+	return uint64(st.clock.Now().Unix())
+}
 
-	// Log the burn operation
-	st.auditLog = append(st.auditLog, SupplyAuditLog{
-		BlockNumber: blockNumber,
-		Amount:      amount,
-		Type:        "burn",
-		Timestamp:   uint64(time.Now().Unix()),
-		Caller:      caller,
-	})
+// entryTimestampOr returns the explicit timestamp when set, and the derived
+// entry timestamp otherwise (caller must hold the lock)
+func (st *SupplyTracker) entryTimestampOr(blockNumber uint64, timestamp *uint64) uint64 {
+	if timestamp != nil {
+		return *timestamp
+	}
 
-	return nil
+	return st.entryTimestamp(blockNumber)
 }
 
-// GetAuditLog returns the immutable audit trail
-func (st *SupplyTracker) GetAuditLog() []SupplyAuditLog {
+// GetTotalSupply calculates total supply from initial supply + all changes
+func (st *SupplyTracker) GetTotalSupply() *big.Int {
 	st.mutex.RLock()
 	defer st.mutex.RUnlock()
 
-	// Return a copy to prevent external modification
-	logCopy := make([]SupplyAuditLog, len(st.auditLog))
-	copy(logCopy, st.auditLog)
-	return logCopy
+	return st.getCurrentSupply()
 }
 
-// getCurrentSupply calculates current supply (internal use)
-func (st *SupplyTracker) getCurrentSupply() *big.Int {
+// GetSupplyAtTimestamp replays the audit log up to and including the given
+// unix timestamp. A timestamp before the first entry yields the initial supply.
+func (st *SupplyTracker) GetSupplyAtTimestamp(unixTs uint64) *big.Int {
+	total, _ := st.GetSupplyAtTimestampCtx(context.Background(), unixTs)
+
+	return total
+}
+
+// GetSupplyAtTimestampCtx is GetSupplyAtTimestamp that stops early with the
+// context error once ctx is done, so long replays don't block shutdown
+func (st *SupplyTracker) GetSupplyAtTimestampCtx(ctx context.Context, unixTs uint64) (*big.Int, error) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
 	total := new(big.Int).Set(st.initialSupply)
-	for _, change := range st.auditLog {
-		if change.Type == "mint" {
+	for i, change := range st.auditLog {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		if change.Timestamp > unixTs {
+			continue
+		}
+
+		if change.Type == ChangeMint {
 			total.Add(total, change.Amount)
-		} else if change.Type == "burn" {
+		} else if change.Type == ChangeBurn {
 			total.Sub(total, change.Amount)
 		}
 	}
-	return total
+
+	return total, nil
 }
 
-// isConsensusEngine validates if the caller is the consensus engine
-func isConsensusEngine(caller string) bool {
-	// Accept both the system address and consensus_engine identifier
-	consensusEngineAddress := "0x0000000000000000000000000000000000000000" // System address
-	consensusEngineIdentifier := "consensus_engine"                        // System identifier
-	return caller == consensusEngineAddress || caller == consensusEngineIdentifier
+// netChangeBetween returns the net supply change for blocks in the half-open
+// (fromBlock, toBlock] range. Blocks within the span of the audit log are summed
+// from the log; the parts of the range before or after that span are taken from
+// uncovered, which returns the change for a half-open range of blocks.
+func (st *SupplyTracker) netChangeBetween(
+	fromBlock, toBlock uint64,
+	uncovered func(fromBlock, toBlock uint64) *big.Int,
+) *big.Int {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	if len(st.auditLog) == 0 {
+		return uncovered(fromBlock, toBlock)
+	}
+
+	first, last := st.auditLog[0].BlockNumber, st.auditLog[0].BlockNumber
+	for _, change := range st.auditLog[1:] {
+		if change.BlockNumber < first {
+			first = change.BlockNumber
+		}
+
+		if change.BlockNumber > last {
+			last = change.BlockNumber
+		}
+	}
+
+	// The log covers the (coveredFrom, last] range
+	coveredFrom := uint64(0)
+	if first > 0 {
+		coveredFrom = first - 1
+	}
+
+	delta := big.NewInt(0)
+
+	if fromBlock < coveredFrom {
+		delta.Add(delta, uncovered(fromBlock, min(toBlock, coveredFrom)))
+	}
+
+	if toBlock > last {
+		delta.Add(delta, uncovered(max(fromBlock, last), toBlock))
+	}
+
+	lo, hi := max(fromBlock, coveredFrom), min(toBlock, last)
+
+	for _, change := range st.auditLog {
+		if change.BlockNumber <= lo || change.BlockNumber > hi {
+			continue
+		}
+
+		if change.Type == ChangeMint {
+			delta.Add(delta, change.Amount)
+		} else if change.Type == ChangeBurn {
+			delta.Sub(delta, change.Amount)
+		}
+	}
+
+	return delta
 }
 
-// getMaxSupply returns the maximum supply limit
-func getMaxSupply() *big.Int {
-	maxSupply, _ := new(big.Int).SetString(MaxSupplyAmount, 10)
-	return maxSupply
+// GetTotalMinted returns the gross amount ever minted according to the audit log
+func (st *SupplyTracker) GetTotalMinted() *big.Int {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	return st.sumByType(ChangeMint)
 }
 
-// System-level supply tracking functions for use in consensus engine
-type SystemSupplyTracker struct {
-	tracker *SupplyTracker
+// GetTotalBurned returns the gross amount ever burned according to the audit log
+func (st *SupplyTracker) GetTotalBurned() *big.Int {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	return st.sumByType(ChangeBurn)
 }
 
-// NewSystemSupplyTracker creates a system-level supply tracker
-func NewSystemSupplyTracker(initialSupply *big.Int) *SystemSupplyTracker {
-	return &SystemSupplyTracker{
-		tracker: NewSupplyTracker(initialSupply),
+// SupplyStats is a consistent snapshot of the tracker state for diagnostics
+type SupplyStats struct {
+	CurrentSupply     *big.Int
+	MaxSupply         *big.Int
+	RemainingMintable *big.Int
+	TotalMinted       *big.Int
+	TotalBurned       *big.Int
+	AuditEntryCount   int
+	// LastBlock is the last block with a recorded supply change, only set when HasLastBlock is true
+	LastBlock    uint64
+	HasLastBlock bool
+}
+
+// Stats returns the tracker state computed under a single read lock, so the
+// values are consistent with each other
+func (st *SupplyTracker) Stats() SupplyStats {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	currentSupply := st.getCurrentSupply()
+	maxSupply := getMaxSupply()
+	lastBlock, hasLastBlock := st.lastBlockLocked()
+
+	return SupplyStats{
+		CurrentSupply:     currentSupply,
+		MaxSupply:         maxSupply,
+		RemainingMintable: remainingMintable(currentSupply, maxSupply),
+		TotalMinted:       st.sumByType(ChangeMint),
+		TotalBurned:       st.sumByType(ChangeBurn),
+		AuditEntryCount:   len(st.auditLog),
+		LastBlock:         lastBlock,
+		HasLastBlock:      hasLastBlock,
 	}
 }
 
-// MintBlockReward securely mints block rewards by calling the internal mint function.
-func (sst *SystemSupplyTracker) MintBlockReward(amount *big.Int, blockNumber uint64) error {
-	return sst.tracker.Mint(amount, blockNumber, "consensus_engine")
+// sumByType returns the running total of the audit log amounts of a single
+// change type (internal use)
+func (st *SupplyTracker) sumByType(changeType SupplyChangeType) *big.Int {
+	switch changeType {
+	case ChangeMint:
+		return new(big.Int).Set(st.minted)
+	case ChangeBurn:
+		return new(big.Int).Set(st.burned)
+	default:
+		return big.NewInt(0)
+	}
 }
 
-// MintRewardWithCap performs a secure, atomic check-and-mint operation for block rewards.
-// It ensures the total supply does not exceed the maximum cap.
-func (sst *SystemSupplyTracker) MintRewardWithCap(txn interface {
-	AddBalance(types.Address, *big.Int)
-}, blockNumber uint64, ownerAddress types.Address) error {
-	sst.tracker.mutex.Lock()
-	defer sst.tracker.mutex.Unlock()
+// addToTotalsLocked adds an entry to the running totals (caller must hold the lock)
+func (st *SupplyTracker) addToTotalsLocked(entry SupplyAuditLog) {
+	switch entry.Type {
+	case ChangeMint:
+		st.minted.Add(st.minted, entry.Amount)
+		addMintedByCaller(st.mintedByCaller, entry.Caller, entry.Amount)
+	case ChangeBurn:
+		st.burned.Add(st.burned, entry.Amount)
+	}
+}
 
-	currentSupply := sst.tracker.getCurrentSupply()
-	maxSupply := getMaxSupply()
+// addMintedByCaller adds amount to the caller's total in totals
+func addMintedByCaller(totals map[string]*big.Int, caller string, amount *big.Int) {
+	total, ok := totals[caller]
+	if !ok {
+		total = big.NewInt(0)
+		totals[caller] = total
+	}
+
+	total.Add(total, amount)
+}
 
-	// Convert to AZE for logging
-	currentSupplyAZE := new(big.Float).Quo(new(big.Float).SetInt(currentSupply), big.NewFloat(1e18))
-	maxSupplyAZE := new(big.Float).Quo(new(big.Float).SetInt(maxSupply), big.NewFloat(1e18))
+// copyMintedByCaller returns a deep copy of per-caller minted totals
+func copyMintedByCaller(totals map[string]*big.Int) map[string]*big.Int {
+	copied := make(map[string]*big.Int, len(totals))
+	for caller, total := range totals {
+		copied[caller] = new(big.Int).Set(total)
+	}
 
-	// Log current state
-	fmt.Printf("[SUPPLY CAP] Block %d: Current Supply = %s AZE, Max Supply = %s AZE\n",
-		blockNumber, currentSupplyAZE.Text('f', 0), maxSupplyAZE.Text('f', 0))
+	return copied
+}
 
-	// If we've already reached or exceeded the max supply, do nothing.
-	if currentSupply.Cmp(maxSupply) >= 0 {
-		fmt.Printf("[SUPPLY CAP] Block %d: Supply cap reached! No reward minted.\n", blockNumber)
-		return nil
+// resetTotalsLocked recomputes the running totals after the audit log was
+// replaced or shortened. The per-caller minted totals start from the ones
+// carried in the snapshot, if any (caller must hold the lock).
+func (st *SupplyTracker) resetTotalsLocked() {
+	st.minted = big.NewInt(0)
+	st.burned = big.NewInt(0)
+	st.mintedByCaller = make(map[string]*big.Int)
+
+	if st.snapshot != nil {
+		st.mintedByCaller = copyMintedByCaller(st.snapshot.MintedByCaller)
 	}
 
-	blockReward := big.NewInt(BlockRewardAmount)
-	originalRewardAZE := new(big.Float).Quo(new(big.Float).SetInt(blockReward), big.NewFloat(1e18))
+	for _, entry := range st.auditLog {
+		st.addToTotalsLocked(entry)
+	}
+}
 
-	// Check if adding the full reward would exceed the max supply.
-	newSupply := new(big.Int).Add(currentSupply, blockReward)
-	if newSupply.Cmp(maxSupply) > 0 {
-		// Only calculate the remaining amount to mint to hit the cap exactly.
-		blockReward = new(big.Int).Sub(maxSupply, currentSupply)
-		if blockReward.Cmp(big.NewInt(0)) <= 0 {
-			fmt.Printf("[SUPPLY CAP] Block %d: No remaining tokens to mint.\n", blockNumber)
-			return nil // No remainder to mint.
-		}
+// Mint securely mints new tokens (only callable from consensus engine)
+//
+// Deprecated: the string caller form is kept for backward compatibility.
+// The system address is delegated to MintAuthorized, so it is only accepted
+// while it is the configured mint authority. New code should use MintAuthorized.
+func (st *SupplyTracker) Mint(amount *big.Int, blockNumber uint64, caller string) error {
+	return st.mint(amount, blockNumber, caller, nil)
+}
 
-		partialRewardAZE := new(big.Float).Quo(new(big.Float).SetInt(blockReward), big.NewFloat(1e18))
-		fmt.Printf("[SUPPLY CAP] Block %d: Partial reward calculated. Original: %s AZE, Partial: %s AZE\n",
-			blockNumber, originalRewardAZE.Text('f', 0), partialRewardAZE.Text('f', 0))
-	} else {
-		fmt.Printf("[SUPPLY CAP] Block %d: Full reward of %s AZE will be minted.\n",
-			blockNumber, originalRewardAZE.Text('f', 0))
+// MintWithTimestamp mints like Mint but records the given timestamp, typically the
+// block header time, instead of one derived from the clock. This keeps the audit
+// log identical across nodes.
+func (st *SupplyTracker) MintWithTimestamp(amount *big.Int, blockNumber uint64, caller string, timestamp uint64) error {
+	return st.mint(amount, blockNumber, caller, &timestamp)
+}
+
+// mint validates the caller and records a mint, at the given timestamp unless nil
+func (st *SupplyTracker) mint(amount *big.Int, blockNumber uint64, caller string, timestamp *uint64) error {
+	if amount == nil || amount.Cmp(big.NewInt(0)) <= 0 {
+		return ErrInvalidAmount
 	}
 
-	// Now, perform the mint operation within the lock.
-	sst.tracker.auditLog = append(sst.tracker.auditLog, SupplyAuditLog{
-		BlockNumber: blockNumber,
-		Amount:      blockReward,
-		Type:        "mint",
-		Timestamp:   uint64(time.Now().Unix()),
-		Caller:      "consensus_engine",
-	})
+	if caller == systemCallerAddress {
+		return st.mintAuthorized(amount, blockNumber, types.StringToAddress(caller), timestamp)
+	}
 
-	// Add the balance to the owner address.
-	txn.AddBalance(ownerAddress, blockReward)
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
 
-	// Log final state
-	finalSupply := new(big.Int).Add(currentSupply, blockReward)
-	finalSupplyAZE := new(big.Float).Quo(new(big.Float).SetInt(finalSupply), big.NewFloat(1e18))
-	rewardAZE := new(big.Float).Quo(new(big.Float).SetInt(blockReward), big.NewFloat(1e18))
+	// Validate caller is consensus engine
+	if !isConsensusEngine(caller) {
+		return newSupplyError(ErrUnauthorizedMint, blockNumber, amount)
+	}
 
-	fmt.Printf("[SUPPLY CAP] Block %d: Reward minted! Amount: %s AZE, New Supply: %s AZE\n",
-		blockNumber, rewardAZE.Text('f', 0), finalSupplyAZE.Text('f', 0))
+	return st.mintLocked(amount, blockNumber, caller, timestamp)
+}
 
-	return nil
+// MintAuthorized mints new tokens on behalf of a typed caller address,
+// which must match the configured mint authority
+func (st *SupplyTracker) MintAuthorized(amount *big.Int, blockNumber uint64, caller types.Address) error {
+	return st.mintAuthorized(amount, blockNumber, caller, nil)
 }
 
-// GetCurrentSupply gets the current total supply
-func (sst *SystemSupplyTracker) GetCurrentSupply() *big.Int {
-	return sst.tracker.GetTotalSupply()
+// mintAuthorized checks the mint authority and records a mint, at the given timestamp unless nil
+func (st *SupplyTracker) mintAuthorized(
+	amount *big.Int,
+	blockNumber uint64,
+	caller types.Address,
+	timestamp *uint64,
+) error {
+	if amount == nil || amount.Cmp(big.NewInt(0)) <= 0 {
+		return ErrInvalidAmount
+	}
+
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	if caller != st.mintAuthority {
+		return newSupplyError(ErrUnauthorizedMint, blockNumber, amount)
+	}
+
+	return st.mintLocked(amount, blockNumber, caller.String(), timestamp)
 }
 
-// GetAuditLog gets the supply audit log
+// SetCapTolerance sets how many wei a mint may overshoot the supply cap by
+// and still be accepted, trimmed to the cap, instead of being rejected
+func (st *SupplyTracker) SetCapTolerance(wei *big.Int) error {
+	if wei == nil || wei.Sign() < 0 {
+		return ErrInvalidAmount
+	}
+
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.capTolerance = new(big.Int).Set(wei)
+
+	return nil
+}
+
+// SetMintAuthority sets the address authorized to mint through MintAuthorized
+func (st *SupplyTracker) SetMintAuthority(addr types.Address) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.mintAuthority = addr
+}
+
+// SetBurnAuthority sets the address authorized to burn, independently of the
+// mint authority. The consensus engine remains authorized to burn regardless.
+func (st *SupplyTracker) SetBurnAuthority(addr types.Address) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.burnAuthority = addr
+}
+
+// isBurnAuthorized reports whether the caller may burn (caller must hold the lock)
+func (st *SupplyTracker) isBurnAuthorized(caller string) bool {
+	// The system address only burns while it is the burn authority
+	if caller != systemCallerAddress && isConsensusEngine(caller) {
+		return true
+	}
+
+	return strings.EqualFold(caller, st.burnAuthority.String())
+}
+
+// SetStrictBlockOrdering toggles strict mode, where a supply change is rejected
+// with ErrNonMonotonicBlock unless its block number is above the last recorded
+// block. This guards against double counting a block processed twice after a
+// restart, but also limits the log to a single entry per block. Off by default.
+func (st *SupplyTracker) SetStrictBlockOrdering(strict bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.strictBlockOrder = strict
+}
+
+// SetMaxMintPerBlock sets the most that may be minted within a single block, acting
+// as a circuit breaker against runaway minting. Mints that would push the block's
+// total above the limit are rejected with ErrMintRateExceeded. A nil amount
+// removes the limit, which is the default.
+func (st *SupplyTracker) SetMaxMintPerBlock(amount *big.Int) error {
+	if amount != nil && amount.Sign() < 0 {
+		return fmt.Errorf("%w: max mint per block must not be negative", ErrInvalidAmount)
+	}
+
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	if amount == nil {
+		st.maxMintPerBlock = nil
+
+		return nil
+	}
+
+	st.maxMintPerBlock = new(big.Int).Set(amount)
+
+	return nil
+}
+
+// SetMintQuota limits the cumulative amount the caller may mint through Mint and
+// MintAuthorized, limiting the damage a compromised module can do. Mints that
+// would take the caller's total above the quota are rejected with
+// ErrQuotaExceeded. Address callers are identified by their String form. A nil
+// quota removes the limit; callers without a quota are unlimited.
+func (st *SupplyTracker) SetMintQuota(caller string, max *big.Int) error {
+	if max != nil && max.Sign() < 0 {
+		return fmt.Errorf("%w: mint quota must not be negative", ErrInvalidAmount)
+	}
+
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	if max == nil {
+		delete(st.mintQuotas, caller)
+
+		return nil
+	}
+
+	if st.mintQuotas == nil {
+		st.mintQuotas = make(map[string]*big.Int)
+	}
+
+	st.mintQuotas[caller] = new(big.Int).Set(max)
+
+	return nil
+}
+
+// GetMintedByCaller returns the total minted by the caller, including mints
+// compacted or imported into a snapshot
+func (st *SupplyTracker) GetMintedByCaller(caller string) *big.Int {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	return st.mintedByCallerLocked(caller)
+}
+
+// mintedByCallerLocked returns the caller's running minted total (caller must hold the lock)
+func (st *SupplyTracker) mintedByCallerLocked(caller string) *big.Int {
+	if total, ok := st.mintedByCaller[caller]; ok {
+		return new(big.Int).Set(total)
+	}
+
+	return big.NewInt(0)
+}
+
+// checkMintQuotaLocked rejects a mint that would take the caller's total above
+// its quota (caller must hold the lock)
+func (st *SupplyTracker) checkMintQuotaLocked(caller string, amount *big.Int, blockNumber uint64) error {
+	quota, ok := st.mintQuotas[caller]
+	if !ok {
+		return nil
+	}
+
+	minted := st.mintedByCallerLocked(caller)
+
+	// Reservations are only made for consensus reward mints
+	if caller == consensusEngineCaller {
+		minted.Add(minted, st.reservedTotalLocked())
+	}
+
+	if total := new(big.Int).Add(minted, amount); total.Cmp(quota) > 0 {
+		getSupplyLogger().Warn("mint rejected by caller quota", "block", blockNumber,
+			"amount", amount.String(), "caller", caller, "quota", quota.String())
+
+		return newSupplyError(fmt.Errorf("%w: %s has minted %s wei, quota %s wei",
+			ErrQuotaExceeded, caller, minted.String(), quota.String()), blockNumber, amount)
+	}
+
+	return nil
+}
+
+// Freeze rejects all mints and burns with ErrSupplyFrozen until Unfreeze is
+// called, giving backup tooling a consistent window to take a snapshot. Writers
+// fail fast instead of waiting, so block processing is never stalled.
+func (st *SupplyTracker) Freeze() {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.frozen = true
+}
+
+// Unfreeze allows mints and burns again after Freeze
+func (st *SupplyTracker) Unfreeze() {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.frozen = false
+}
+
+// IsFrozen reports whether the tracker is frozen
+func (st *SupplyTracker) IsFrozen() bool {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	return st.frozen
+}
+
+// checkFrozenLocked rejects writes while the tracker is frozen (caller must hold the lock)
+func (st *SupplyTracker) checkFrozenLocked(amount *big.Int, blockNumber uint64) error {
+	if st.frozen {
+		return newSupplyError(ErrSupplyFrozen, blockNumber, amount)
+	}
+
+	return nil
+}
+
+// checkMintRateLocked rejects a mint that would take the amount minted in the
+// block above the per-block limit (caller must hold the lock)
+func (st *SupplyTracker) checkMintRateLocked(amount *big.Int, blockNumber uint64) error {
+	if st.maxMintPerBlock == nil {
+		return nil
+	}
+
+	minted := new(big.Int).Set(amount)
+	if reserved, ok := st.reserved[blockNumber]; ok {
+		minted.Add(minted, reserved)
+	}
+
+	// Entries of the same block sit at the tail of the log
+	for i := len(st.auditLog) - 1; i >= 0 && st.auditLog[i].BlockNumber == blockNumber; i-- {
+		if st.auditLog[i].Type == ChangeMint {
+			minted.Add(minted, st.auditLog[i].Amount)
+		}
+	}
+
+	if minted.Cmp(st.maxMintPerBlock) > 0 {
+		getSupplyLogger().Warn("mint rejected by per-block limit", "block", blockNumber,
+			"amount", amount.String(), "limit", st.maxMintPerBlock.String())
+
+		return newSupplyError(fmt.Errorf("%w: %s wei minted in block, limit %s wei",
+			ErrMintRateExceeded, minted.String(), st.maxMintPerBlock.String()), blockNumber, amount)
+	}
+
+	return nil
+}
+
+// ForgetBlock clears a block hash from the set of rewarded blocks
+func (st *SupplyTracker) ForgetBlock(hash types.Hash) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	delete(st.mintedBlocks, hash)
+}
+
+// isBlockMintedLocked reports whether the block hash was already rewarded (caller must hold the lock)
+func (st *SupplyTracker) isBlockMintedLocked(hash types.Hash) bool {
+	_, ok := st.mintedBlocks[hash]
+
+	return ok
+}
+
+// markBlockMintedLocked records the block hash as rewarded (caller must hold the lock)
+func (st *SupplyTracker) markBlockMintedLocked(hash types.Hash, blockNumber uint64) {
+	if st.mintedBlocks == nil {
+		st.mintedBlocks = make(map[types.Hash]uint64)
+	}
+
+	st.mintedBlocks[hash] = blockNumber
+}
+
+// checkBlockOrderLocked enforces strict block ordering when enabled (caller must hold the lock)
+func (st *SupplyTracker) checkBlockOrderLocked(blockNumber uint64) error {
+	if !st.strictBlockOrder {
+		return nil
+	}
+
+	if last, ok := st.lastBlockLocked(); ok && blockNumber <= last {
+		return fmt.Errorf("%w: block %d, last recorded block %d", ErrNonMonotonicBlock, blockNumber, last)
+	}
+
+	return nil
+}
+
+// lastBlockLocked returns the last recorded block, falling back to the block of
+// an imported or compacted snapshot when the log is empty (caller must hold the lock)
+func (st *SupplyTracker) lastBlockLocked() (uint64, bool) {
+	if len(st.auditLog) > 0 {
+		return st.auditLog[len(st.auditLog)-1].BlockNumber, true
+	}
+
+	if st.snapshot != nil {
+		return st.snapshot.LastBlock, true
+	}
+
+	return 0, false
+}
+
+// mintLocked checks the cap and records a mint, at the given timestamp unless nil
+// (caller must hold the lock)
+func (st *SupplyTracker) mintLocked(amount *big.Int, blockNumber uint64, caller string, timestamp *uint64) error {
+	if err := st.checkFrozenLocked(amount, blockNumber); err != nil {
+		return err
+	}
+
+	if err := st.checkBlockOrderLocked(blockNumber); err != nil {
+		return err
+	}
+
+	// The cap check is now handled in MintBlockReward, so we only log here.
+	// This prevents a double-check that was causing the partial reward to be rejected.
+	currentSupply := st.getCurrentSupply()
+	maxSupply := getMaxSupply()
+	newSupply := new(big.Int).Add(currentSupply, amount)
+
+	if newSupply.Cmp(maxSupply) > 0 {
+		overshoot := new(big.Int).Sub(newSupply, maxSupply)
+
+		// Overshoots within the tolerance are trimmed to hit the cap exactly,
+		// as long as there is anything left to mint
+		if currentSupply.Cmp(maxSupply) >= 0 || overshoot.Cmp(st.capTolerance) > 0 {
+			// We still check here to be absolutely safe, but the primary logic
+			// in MintBlockReward should prevent this from being reached with an invalid amount.
+			// If it is reached, we return the error to halt the process.
+			capReachedCounter.Inc()
+
+			return newSupplyError(ErrSupplyCapExceeded, blockNumber, amount)
+		}
+
+		amount = new(big.Int).Sub(maxSupply, currentSupply)
+	}
+
+	if err := st.checkMintRateLocked(amount, blockNumber); err != nil {
+		return err
+	}
+
+	if err := st.checkMintQuotaLocked(caller, amount, blockNumber); err != nil {
+		return err
+	}
+
+	// Log the mint operation
+	st.appendEntryLocked(SupplyAuditLog{
+		BlockNumber: blockNumber,
+		Amount:      amount,
+		Type:        ChangeMint,
+		Timestamp:   st.entryTimestampOr(blockNumber, timestamp),
+		Caller:      caller,
+	})
+
+	return nil
+}
+
+// Burn securely burns tokens (only callable from consensus engine or the burn authority)
+func (st *SupplyTracker) Burn(amount *big.Int, blockNumber uint64, caller string) error {
+	return st.BurnWithReason(amount, blockNumber, caller, "")
+}
+
+// BurnWithReason burns tokens like Burn and records why the burn happened
+func (st *SupplyTracker) BurnWithReason(amount *big.Int, blockNumber uint64, caller string, reason string) error {
+	return st.burn(amount, blockNumber, caller, reason, nil)
+}
+
+// BurnWithTimestamp burns like Burn but records the given timestamp, typically the
+// block header time, instead of one derived from the clock
+func (st *SupplyTracker) BurnWithTimestamp(amount *big.Int, blockNumber uint64, caller string, timestamp uint64) error {
+	return st.burn(amount, blockNumber, caller, "", &timestamp)
+}
+
+// burn validates the caller and records a burn, at the given timestamp unless nil
+func (st *SupplyTracker) burn(
+	amount *big.Int,
+	blockNumber uint64,
+	caller string,
+	reason string,
+	timestamp *uint64,
+) error {
+	if amount == nil || amount.Cmp(big.NewInt(0)) <= 0 {
+		return ErrInvalidAmount
+	}
+
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	// Validate caller is the consensus engine or the burn authority
+	if !st.isBurnAuthorized(caller) {
+		return newSupplyError(ErrUnauthorizedBurn, blockNumber, amount)
+	}
+
+	return st.burnLocked(amount, blockNumber, caller, reason, timestamp)
+}
+
+// BurnCapped burns min(amount, current supply) instead of failing when the amount
+// exceeds the supply, and returns how much was actually burned. Nothing is
+// recorded when the supply is already zero.
+func (st *SupplyTracker) BurnCapped(amount *big.Int, blockNumber uint64, caller string) (*big.Int, error) {
+	if amount == nil || amount.Cmp(big.NewInt(0)) <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	if !st.isBurnAuthorized(caller) {
+		return nil, newSupplyError(ErrUnauthorizedBurn, blockNumber, amount)
+	}
+
+	burned := new(big.Int).Set(amount)
+	if currentSupply := st.getCurrentSupply(); currentSupply.Cmp(burned) < 0 {
+		burned = currentSupply
+	}
+
+	if burned.Sign() <= 0 {
+		return big.NewInt(0), nil
+	}
+
+	if err := st.burnLocked(burned, blockNumber, caller, "", nil); err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).Set(burned), nil
+}
+
+// burnLocked checks the supply and records a burn, at the given timestamp unless nil
+// (caller must hold the lock)
+func (st *SupplyTracker) burnLocked(
+	amount *big.Int,
+	blockNumber uint64,
+	caller string,
+	reason string,
+	timestamp *uint64,
+) error {
+	if err := st.checkFrozenLocked(amount, blockNumber); err != nil {
+		return err
+	}
+
+	if err := st.checkBlockOrderLocked(blockNumber); err != nil {
+		return err
+	}
+
+	// Check sufficient supply
+	currentSupply := st.getCurrentSupply()
+	if currentSupply.Cmp(amount) < 0 {
+		return newSupplyError(ErrInsufficientSupply, blockNumber, amount)
+	}
+
+	// Log the burn operation
+	st.appendEntryLocked(SupplyAuditLog{
+		BlockNumber: blockNumber,
+		Amount:      amount,
+		Type:        ChangeBurn,
+		Timestamp:   st.entryTimestampOr(blockNumber, timestamp),
+		Caller:      caller,
+		Reason:      reason,
+	})
+
+	return nil
+}
+
+// GetAuditLog returns the immutable audit trail
+func (st *SupplyTracker) GetAuditLog() []SupplyAuditLog {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	// Return a copy to prevent external modification
+	logCopy := make([]SupplyAuditLog, len(st.auditLog))
+	copy(logCopy, st.auditLog)
+	return logCopy
+}
+
+// GetAuditLogPaginated returns copies of at most limit audit entries starting at
+// offset, together with the total number of entries. A negative offset is treated
+// as zero and a negative limit as zero, and the window is cut at the end of the
+// log, so an offset past the end yields an empty slice.
+func (st *SupplyTracker) GetAuditLogPaginated(offset, limit int) ([]SupplyAuditLog, int) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	total := len(st.auditLog)
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	if limit < 0 {
+		limit = 0
+	}
+
+	if offset > total {
+		offset = total
+	}
+
+	end := total
+	if limit < total-offset {
+		end = offset + limit
+	}
+
+	page := make([]SupplyAuditLog, 0, end-offset)
+	for _, entry := range st.auditLog[offset:end] {
+		page = append(page, copyAuditEntry(entry))
+	}
+
+	return page, total
+}
+
+// GetLastAuditEntry returns a copy of the most recent audit entry,
+// and false when the log is empty
+func (st *SupplyTracker) GetLastAuditEntry() (SupplyAuditLog, bool) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	if len(st.auditLog) == 0 {
+		return SupplyAuditLog{}, false
+	}
+
+	return copyAuditEntry(st.auditLog[len(st.auditLog)-1]), true
+}
+
+// LastMintBlock returns the block of the most recent mint in the audit log, or 0
+// when none is recorded
+func (st *SupplyTracker) LastMintBlock() uint64 {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	for i := len(st.auditLog) - 1; i >= 0; i-- {
+		if st.auditLog[i].Type == ChangeMint {
+			return st.auditLog[i].BlockNumber
+		}
+	}
+
+	return 0
+}
+
+// BlocksSinceLastMint returns how many blocks passed between the last mint and
+// currentBlock, so a monitor can detect stalled emission. Together with
+// CanMintAtBlock it tells a capped supply apart from a broken reward path.
+func (st *SupplyTracker) BlocksSinceLastMint(currentBlock uint64) uint64 {
+	if last := st.LastMintBlock(); currentBlock > last {
+		return currentBlock - last
+	}
+
+	return 0
+}
+
+// AuditLogLen returns the number of audit entries without copying the log
+func (st *SupplyTracker) AuditLogLen() int {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	return len(st.auditLog)
+}
+
+// GetAuditLogByCaller returns a copy of the audit entries recorded for the
+// exact, case-sensitive caller string
+func (st *SupplyTracker) GetAuditLogByCaller(caller string) []SupplyAuditLog {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	entries := make([]SupplyAuditLog, 0)
+	for _, entry := range st.auditLog {
+		if entry.Caller == caller {
+			entries = append(entries, copyAuditEntry(entry))
+		}
+	}
+
+	return entries
+}
+
+// EntriesSince returns a copy of the audit entries from the given index onward,
+// letting streaming consumers resume from the last index they processed.
+// The index is clamped to the bounds of the log.
+func (st *SupplyTracker) EntriesSince(index int) []SupplyAuditLog {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	if index < 0 {
+		index = 0
+	}
+
+	if index > len(st.auditLog) {
+		index = len(st.auditLog)
+	}
+
+	entries := make([]SupplyAuditLog, len(st.auditLog)-index)
+	copy(entries, st.auditLog[index:])
+
+	return entries
+}
+
+// RewindAndReplay atomically drops every audit entry above toBlock and appends
+// the replacement entries, modelling the switch to an alternate chain branch.
+// The replay must start after toBlock and pass VerifyAuditLogConsistency from the
+// rewound supply against the max supply, so it is ordered by block and never
+// takes the supply below zero or above the cap. It is rejected while frozen.
+func (st *SupplyTracker) RewindAndReplay(toBlock uint64, replay []SupplyAuditLog) error {
+	if len(replay) > 0 && replay[0].BlockNumber <= toBlock {
+		return fmt.Errorf("%w: entry 0 at block %d does not start after block %d",
+			ErrInvalidReplay, replay[0].BlockNumber, toBlock)
+	}
+
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	if err := st.checkFrozenLocked(nil, toBlock); err != nil {
+		return err
+	}
+
+	// The supply once every entry above toBlock is dropped
+	rewound := st.getCurrentSupply()
+
+	for _, change := range st.auditLog {
+		if change.BlockNumber <= toBlock {
+			continue
+		}
+
+		if change.Type == ChangeMint {
+			rewound.Sub(rewound, change.Amount)
+		} else if change.Type == ChangeBurn {
+			rewound.Add(rewound, change.Amount)
+		}
+	}
+
+	if err := VerifyAuditLogConsistency(replay, rewound, getMaxSupply()); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidReplay, err)
+	}
+
+	st.revertAbove(toBlock)
+
+	for _, entry := range replay {
+		entry.Amount = new(big.Int).Set(entry.Amount)
+		st.appendEntryLocked(entry)
+	}
+
+	return nil
+}
+
+// ReplaceAuditLog atomically installs a verified audit log and initial supply,
+// e.g. one received from a trusted peer during fast sync. The replacement must
+// pass VerifyAuditLogConsistency against the current max supply and its hash
+// chain must verify as received; otherwise the tracker is left untouched. Any
+// imported or compacted snapshot is discarded.
+func (st *SupplyTracker) ReplaceAuditLog(entries []SupplyAuditLog, initialSupply *big.Int) error {
+	if err := VerifyAuditLogConsistency(entries, initialSupply, getMaxSupply()); err != nil {
+		return err
+	}
+
+	if err := verifyAuditChain(entries); err != nil {
+		return err
+	}
+
+	auditLog := make([]SupplyAuditLog, len(entries))
+	for i, entry := range entries {
+		auditLog[i] = copyAuditEntry(entry)
+	}
+
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.initialSupply = new(big.Int).Set(initialSupply)
+	st.auditLog = auditLog
+	st.snapshot = nil
+	st.resetTotalsLocked()
+	st.updateMetrics()
+
+	return nil
+}
+
+// appendEntryLocked records an audit entry, refreshes the metrics and publishes
+// the entry to subscribers (caller must hold the lock)
+func (st *SupplyTracker) appendEntryLocked(entry SupplyAuditLog) {
+	st.insertEntryLocked(len(st.auditLog), entry)
+}
+
+// insertEntryLocked records the entry at position i of the audit log, links it and
+// the entries after it into the hash chain, refreshes the metrics and notifies
+// subscribers (caller must hold the lock)
+func (st *SupplyTracker) insertEntryLocked(i int, entry SupplyAuditLog) {
+	st.auditLog = append(st.auditLog, SupplyAuditLog{})
+	copy(st.auditLog[i+1:], st.auditLog[i:])
+	st.auditLog[i] = entry
+	st.relinkFromLocked(i)
+	entry = st.auditLog[i]
+	st.addToTotalsLocked(entry)
+
+	if entry.Type == ChangeMint {
+		warnAboveSoftCap(entry.BlockNumber, st.getCurrentSupply())
+	}
+
+	st.updateMetrics()
+	st.publishLocked(entry)
+	notifySupplyWebhook(entry)
+}
+
+// revertAbove drops all audit entries recorded above the given block (internal use)
+func (st *SupplyTracker) revertAbove(toBlock uint64) {
+	kept := st.auditLog[:0]
+	for _, change := range st.auditLog {
+		if change.BlockNumber <= toBlock {
+			kept = append(kept, change)
+		}
+	}
+
+	st.auditLog = kept
+	st.relinkFromLocked(0)
+	st.resetTotalsLocked()
+}
+
+// initialSupplyAndLog returns copies of the initial supply and the audit log,
+// taken together under the lock so they describe the same state
+func (st *SupplyTracker) initialSupplyAndLog() (*big.Int, []SupplyAuditLog) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	log := make([]SupplyAuditLog, len(st.auditLog))
+	for i, entry := range st.auditLog {
+		log[i] = copyAuditEntry(entry)
+	}
+
+	return new(big.Int).Set(st.initialSupply), log
+}
+
+// CompareTrackers walks the audit logs of two trackers in lockstep and reports the
+// first block where they differ in amount, type or presence of an entry.
+// Differing initial supplies are reported as a difference at block 0. Each tracker
+// is read once under its lock, so the comparison is made between consistent states.
+func CompareTrackers(a, b *SupplyTracker) (bool, uint64, error) {
+	if a == nil || b == nil {
+		return false, 0, errors.New("cannot compare nil supply trackers")
+	}
+
+	initialA, logA := a.initialSupplyAndLog()
+	initialB, logB := b.initialSupplyAndLog()
+
+	if initialA.Cmp(initialB) != 0 {
+		return false, 0, nil
+	}
+
+	for i := 0; i < len(logA) && i < len(logB); i++ {
+		entryA, entryB := logA[i], logB[i]
+
+		if entryA.BlockNumber != entryB.BlockNumber {
+			// One log has an entry for a block the other does not
+			if entryA.BlockNumber < entryB.BlockNumber {
+				return false, entryA.BlockNumber, nil
+			}
+
+			return false, entryB.BlockNumber, nil
+		}
+
+		if entryA.Type != entryB.Type || entryA.Amount.Cmp(entryB.Amount) != 0 {
+			return false, entryA.BlockNumber, nil
+		}
+	}
+
+	switch {
+	case len(logA) > len(logB):
+		return false, logA[len(logB)].BlockNumber, nil
+	case len(logB) > len(logA):
+		return false, logB[len(logA)].BlockNumber, nil
+	}
+
+	return true, 0, nil
+}
+
+// getCurrentSupply calculates current supply (internal use)
+func (st *SupplyTracker) getCurrentSupply() *big.Int {
+	total := new(big.Int).Add(st.initialSupply, st.minted)
+	total.Add(total, st.reservedTotalLocked())
+
+	return total.Sub(total, st.burned)
+}
+
+// reservedTotalLocked returns the sum of the reserved reward mints (caller must hold the lock)
+func (st *SupplyTracker) reservedTotalLocked() *big.Int {
+	total := big.NewInt(0)
+	for _, amount := range st.reserved {
+		total.Add(total, amount)
+	}
+
+	return total
+}
+
+// reserveLocked reserves a reward mint at the block (caller must hold the lock)
+func (st *SupplyTracker) reserveLocked(blockNumber uint64, amount *big.Int) {
+	if st.reserved == nil {
+		st.reserved = make(map[uint64]*big.Int)
+	}
+
+	reserved, ok := st.reserved[blockNumber]
+	if !ok {
+		reserved = big.NewInt(0)
+		st.reserved[blockNumber] = reserved
+	}
+
+	reserved.Add(reserved, amount)
+}
+
+// releaseReservation drops a reward mint reserved at the block without recording it
+func (st *SupplyTracker) releaseReservation(blockNumber uint64, amount *big.Int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.releaseReservationLocked(blockNumber, amount)
+}
+
+// releaseReservationLocked drops a reservation (caller must hold the lock)
+func (st *SupplyTracker) releaseReservationLocked(blockNumber uint64, amount *big.Int) {
+	reserved, ok := st.reserved[blockNumber]
+	if !ok {
+		return
+	}
+
+	if reserved.Sub(reserved, amount).Sign() <= 0 {
+		delete(st.reserved, blockNumber)
+	}
+}
+
+// commitReservation records a reward mint reserved at the block as minted to the
+// recipient and reports whether the supply reached the cap with it
+func (st *SupplyTracker) commitReservation(blockNumber uint64, amount *big.Int, recipient types.Address) bool {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.releaseReservationLocked(blockNumber, amount)
+	st.appendEntryLocked(SupplyAuditLog{
+		BlockNumber: blockNumber,
+		Amount:      new(big.Int).Set(amount),
+		Type:        ChangeMint,
+		Timestamp:   st.entryTimestamp(blockNumber),
+		Caller:      consensusEngineCaller,
+		Recipient:   &recipient,
+	})
+
+	return st.getCurrentSupply().Cmp(getMaxSupply()) >= 0
+}
+
+// isConsensusEngine validates if the caller is a registered consensus engine identifier
+func isConsensusEngine(caller string) bool {
+	consensusCallersLock.RLock()
+	defer consensusCallersLock.RUnlock()
+
+	_, ok := consensusCallers[caller]
+
+	return ok
+}
+
+// defaultConsensusCallers returns the identifiers accepted out of the box:
+// the system address and the consensus_engine identifier
+func defaultConsensusCallers() map[string]struct{} {
+	return map[string]struct{}{
+		systemCallerAddress:   {},
+		consensusEngineCaller: {},
+	}
+}
+
+// RegisterConsensusCaller adds an identifier accepted as the consensus engine,
+// for chains whose consensus module uses a different name
+func RegisterConsensusCaller(id string) {
+	consensusCallersLock.Lock()
+	defer consensusCallersLock.Unlock()
+
+	consensusCallers[id] = struct{}{}
+}
+
+// ClearConsensusCallers drops every registered identifier and restores the defaults
+func ClearConsensusCallers() {
+	consensusCallersLock.Lock()
+	defer consensusCallersLock.Unlock()
+
+	consensusCallers = defaultConsensusCallers()
+}
+
+// getMaxSupply returns the maximum supply limit
+func getMaxSupply() *big.Int {
+	maxSupplyLock.RLock()
+	defer maxSupplyLock.RUnlock()
+
+	return new(big.Int).Set(maxSupplyWei)
+}
+
+// SetSoftCap sets a warning threshold below the max supply, e.g. when the max
+// supply is raised above the nominal cap for a testnet experiment. Mints that take
+// the supply above the soft cap still succeed but log a warning; only the max
+// supply is enforced. A nil soft cap makes it equal the max supply again, which
+// is the default. A soft cap above the current max supply is rejected, and one
+// left above a later lowered max supply is clamped to it.
+func SetSoftCap(soft *big.Int) error {
+	if soft != nil && soft.Sign() <= 0 {
+		return ErrInvalidAmount
+	}
+
+	maxSupplyLock.Lock()
+	defer maxSupplyLock.Unlock()
+
+	if soft == nil {
+		softCapWei = nil
+
+		return nil
+	}
+
+	if soft.Cmp(maxSupplyWei) > 0 {
+		return fmt.Errorf("%w: soft cap %s wei is above max supply %s wei",
+			ErrInvalidMaxSupply, soft.String(), maxSupplyWei.String())
+	}
+
+	softCapWei = new(big.Int).Set(soft)
+
+	return nil
+}
+
+// GetSoftCap returns the supply above which mints log a warning
+func GetSoftCap() *big.Int {
+	maxSupplyLock.RLock()
+	defer maxSupplyLock.RUnlock()
+
+	if softCapWei == nil || softCapWei.Cmp(maxSupplyWei) > 0 {
+		return new(big.Int).Set(maxSupplyWei)
+	}
+
+	return new(big.Int).Set(softCapWei)
+}
+
+// warnAboveSoftCap warns through the SupplyLogger when a mint took the supply
+// into the band between the soft cap and the max supply
+func warnAboveSoftCap(blockNumber uint64, supply *big.Int) {
+	if softCap := GetSoftCap(); supply.Cmp(softCap) > 0 {
+		getSupplyLogger().Warn("supply is above the soft cap",
+			"block", blockNumber, "supply", FormatAZE(supply), "softCap", FormatAZE(softCap))
+	}
+}
+
+// SetMintingEnabled enables or pauses block reward minting, e.g. during emergency
+// maintenance. While paused, reward minting returns ErrMintingPaused without
+// touching balances or the audit log.
+func SetMintingEnabled(enabled bool) {
+	mintingLock.Lock()
+	defer mintingLock.Unlock()
+
+	mintingEnabled = enabled
+}
+
+// isMintingEnabled reports whether block reward minting is enabled
+func isMintingEnabled() bool {
+	mintingLock.RLock()
+	defer mintingLock.RUnlock()
+
+	return mintingEnabled
+}
+
+// SetTokenDecimals sets the number of decimals used to render wei amounts
+func SetTokenDecimals(d uint8) {
+	tokenDecimalsLock.Lock()
+	defer tokenDecimalsLock.Unlock()
+
+	TokenDecimals = d
+}
+
+// weiToTokenFloat converts a wei amount into whole tokens using TokenDecimals
+func weiToTokenFloat(wei *big.Int) *big.Float {
+	scale := tokenScale(tokenDecimals())
+
+	return new(big.Float).Quo(new(big.Float).SetInt(wei), new(big.Float).SetInt(scale))
+}
+
+// computeMintableReward returns how much of the reward can be minted without
+// pushing the current supply above the max supply: the full reward, the
+// remainder up to the cap, or zero once the cap has been reached
+func computeMintableReward(currentSupply, reward, maxSupply *big.Int) *big.Int {
+	remaining := new(big.Int).Sub(maxSupply, currentSupply)
+	if remaining.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+
+	if reward.Cmp(remaining) > 0 {
+		return remaining
+	}
+
+	return new(big.Int).Set(reward)
+}
+
+// SetMaxSupply overrides the maximum supply, e.g. to exercise the cap on test networks.
+// A max supply below the genesis total is rejected since the chain would start capped.
+func SetMaxSupply(max *big.Int) error {
+	if max == nil || max.Sign() <= 0 {
+		return ErrInvalidAmount
+	}
+
+	if genesisTotal := getGenesisTotal(); max.Cmp(genesisTotal) < 0 {
+		return fmt.Errorf("%w: max supply %s wei is below genesis total %s wei",
+			ErrInvalidMaxSupply, max.String(), genesisTotal.String())
+	}
+
+	maxSupplyLock.Lock()
+	defer maxSupplyLock.Unlock()
+
+	maxSupplyWei = new(big.Int).Set(max)
+
+	return nil
+}
+
+// System-level supply tracking functions for use in consensus engine
+type SystemSupplyTracker struct {
+	tracker *SupplyTracker
+}
+
+// NewSystemSupplyTracker creates a system-level supply tracker
+func NewSystemSupplyTracker(initialSupply *big.Int) *SystemSupplyTracker {
+	return &SystemSupplyTracker{
+		tracker: NewSupplyTracker(initialSupply),
+	}
+}
+
+// SetMaxMintPerBlock sets the per-block mint limit of the underlying tracker
+func (sst *SystemSupplyTracker) SetMaxMintPerBlock(amount *big.Int) error {
+	return sst.tracker.SetMaxMintPerBlock(amount)
+}
+
+// GetInitialSupply returns a copy of the initial supply of the underlying tracker
+func (sst *SystemSupplyTracker) GetInitialSupply() *big.Int {
+	return sst.tracker.GetInitialSupply()
+}
+
+// SetInitialSupply sets the initial supply of the underlying tracker; see
+// SupplyTracker.SetInitialSupply
+func (sst *SystemSupplyTracker) SetInitialSupply(supply *big.Int) error {
+	return sst.tracker.SetInitialSupply(supply)
+}
+
+// Freeze rejects all mints and burns until Unfreeze is called; see SupplyTracker.Freeze
+func (sst *SystemSupplyTracker) Freeze() {
+	sst.tracker.Freeze()
+}
+
+// Unfreeze allows mints and burns again after Freeze
+func (sst *SystemSupplyTracker) Unfreeze() {
+	sst.tracker.Unfreeze()
+}
+
+// IsFrozen reports whether the tracker is frozen
+func (sst *SystemSupplyTracker) IsFrozen() bool {
+	return sst.tracker.IsFrozen()
+}
+
+// MintBlockReward securely mints block rewards by calling the internal mint function.
+func (sst *SystemSupplyTracker) MintBlockReward(amount *big.Int, blockNumber uint64) error {
+	if !isMintingEnabled() {
+		return newSupplyError(ErrMintingPaused, blockNumber, amount)
+	}
+
+	return sst.tracker.Mint(amount, blockNumber, "consensus_engine")
+}
+
+// MintBlockRewardForBlock mints like MintBlockReward, once per block hash, and
+// reports whether anything was minted. A block hash that was already rewarded is
+// skipped without error; see MintRewardWithCapForBlock.
+func (sst *SystemSupplyTracker) MintBlockRewardForBlock(
+	amount *big.Int,
+	blockNumber uint64,
+	blockHash types.Hash,
+) (bool, error) {
+	if amount == nil || amount.Sign() <= 0 {
+		return false, ErrInvalidAmount
+	}
+
+	if !isMintingEnabled() {
+		return false, newSupplyError(ErrMintingPaused, blockNumber, amount)
+	}
+
+	sst.tracker.mutex.Lock()
+	defer sst.tracker.mutex.Unlock()
+
+	if sst.tracker.isBlockMintedLocked(blockHash) {
+		getSupplyLogger().Debug("block reward already minted, skipping", "block", blockNumber, "hash", blockHash)
+
+		return false, nil
+	}
+
+	if err := sst.tracker.mintLocked(amount, blockNumber, consensusEngineCaller, nil); err != nil {
+		return false, err
+	}
+
+	sst.tracker.markBlockMintedLocked(blockHash, blockNumber)
+
+	return true, nil
+}
+
+// MintResult describes the outcome of a capped reward mint
+type MintResult struct {
+	// Minted is the amount actually minted, zero if nothing was minted
+	Minted *big.Int
+	// CapReached is true when the supply is at the cap after the operation
+	CapReached bool
+	// Partial is true when the reward was clamped to hit the cap exactly
+	Partial bool
+	// AlreadyMinted is true when the block hash was already rewarded and the mint was skipped
+	AlreadyMinted bool
+}
+
+// MintRewardWithCap performs a secure, atomic check-and-mint operation for block rewards.
+// It ensures the total supply does not exceed the maximum cap and reports
+// whether the full reward, a partial reward or nothing was minted. The first time
+// the cap is reached the OnCapReached callback runs.
+func (sst *SystemSupplyTracker) MintRewardWithCap(txn interface {
+	AddBalance(types.Address, *big.Int)
+}, blockNumber uint64, ownerAddress types.Address) (MintResult, error) {
+	if !isMintingEnabled() {
+		return MintResult{Minted: big.NewInt(0)}, newSupplyError(ErrMintingPaused, blockNumber, big.NewInt(BlockRewardAmount))
+	}
+
+	return sst.mintRewards(blockNumber, func() (MintResult, error) {
+		return sst.mintRewardWithCapLocked(txn, blockNumber, ownerAddress, big.NewInt(BlockRewardAmount))
+	})
+}
+
+// MintRewardWithCapForBlock mints the capped block reward like MintRewardWithCap,
+// once per block hash. When the hash was already rewarded, e.g. because the block
+// is processed again after a reorg, nothing is minted and AlreadyMinted is set.
+// Use ForgetBlock to clear an orphaned block before minting its replacement.
+func (sst *SystemSupplyTracker) MintRewardWithCapForBlock(txn interface {
+	AddBalance(types.Address, *big.Int)
+}, blockNumber uint64, blockHash types.Hash, ownerAddress types.Address) (MintResult, error) {
+	if !isMintingEnabled() {
+		return MintResult{Minted: big.NewInt(0)}, newSupplyError(ErrMintingPaused, blockNumber, big.NewInt(BlockRewardAmount))
+	}
+
+	return sst.mintRewards(blockNumber, func() (MintResult, error) {
+		if sst.tracker.isBlockMintedLocked(blockHash) {
+			getSupplyLogger().Debug("block reward already minted, skipping", "block", blockNumber, "hash", blockHash)
+
+			return MintResult{Minted: big.NewInt(0), AlreadyMinted: true}, nil
+		}
+
+		result, err := sst.mintRewardWithCapLocked(txn, blockNumber, ownerAddress, big.NewInt(BlockRewardAmount))
+		if err != nil {
+			return result, err
+		}
+
+		sst.tracker.markBlockMintedLocked(blockHash, blockNumber)
+
+		return result, nil
+	})
+}
+
+// ForgetBlock clears a block hash from the set of rewarded blocks, so an orphaned
+// block does not keep its hash marked after a reorg
+func (sst *SystemSupplyTracker) ForgetBlock(hash types.Hash) {
+	sst.tracker.ForgetBlock(hash)
+}
+
+// MintBlockRewardScaled mints the capped block reward scaled by the fraction of
+// active validators, activeValidators/maxValidators, so issuance follows network
+// participation. The scale never exceeds 1.0, and a zero maxValidators disables
+// scaling. The scaled amount is checked against the cap and recorded.
+func (sst *SystemSupplyTracker) MintBlockRewardScaled(
+	txn interface{ AddBalance(types.Address, *big.Int) },
+	blockNumber uint64,
+	ownerAddress types.Address,
+	activeValidators, maxValidators uint64,
+) (MintResult, error) {
+	reward := scaleReward(big.NewInt(BlockRewardAmount), activeValidators, maxValidators)
+
+	if !isMintingEnabled() {
+		return MintResult{Minted: big.NewInt(0)}, newSupplyError(ErrMintingPaused, blockNumber, reward)
+	}
+
+	if reward.Sign() == 0 {
+		getSupplyLogger().Debug("no active validators, no reward minted", "block", blockNumber)
+
+		return MintResult{Minted: big.NewInt(0)}, nil
+	}
+
+	return sst.mintRewards(blockNumber, func() (MintResult, error) {
+		return sst.mintRewardWithCapLocked(txn, blockNumber, ownerAddress, reward)
+	})
+}
+
+// MintBlockRewardRotating mints the capped block reward like MintRewardWithCap,
+// paying block N to recipients[N % len(recipients)] so issuance rotates through
+// the list round-robin. The recipient is recorded in the audit entry. An empty
+// list is rejected with ErrNoRewardRecipients, since there is no owner to fall
+// back to; use MintRewardWithCap with an explicit owner instead.
+func (sst *SystemSupplyTracker) MintBlockRewardRotating(
+	txn interface{ AddBalance(types.Address, *big.Int) },
+	blockNumber uint64,
+	recipients []types.Address,
+) (MintResult, error) {
+	if len(recipients) == 0 {
+		return MintResult{Minted: big.NewInt(0)}, fmt.Errorf("%w at block %d: an explicit owner is required",
+			ErrNoRewardRecipients, blockNumber)
+	}
+
+	recipient := recipients[blockNumber%uint64(len(recipients))]
+
+	return sst.MintRewardWithCap(txn, blockNumber, recipient)
+}
+
+// scaleReward returns reward * activeValidators / maxValidators, leaving the
+// reward unscaled when maxValidators is zero and never scaling above the full reward
+func scaleReward(reward *big.Int, activeValidators, maxValidators uint64) *big.Int {
+	if maxValidators == 0 || activeValidators >= maxValidators {
+		return new(big.Int).Set(reward)
+	}
+
+	scaled := new(big.Int).Mul(reward, new(big.Int).SetUint64(activeValidators))
+
+	return scaled.Div(scaled, new(big.Int).SetUint64(maxValidators))
+}
+
+// mintRewards runs mint under the tracker lock and, once the lock is released,
+// notifies the OnCapReached callback when the mint reached the supply cap
+func (sst *SystemSupplyTracker) mintRewards(blockNumber uint64, mint func() (MintResult, error)) (MintResult, error) {
+	sst.tracker.mutex.Lock()
+	result, err := mint()
+	sst.tracker.mutex.Unlock()
+
+	if err == nil && result.CapReached {
+		notifyCapReached(blockNumber)
+	}
+
+	return result, err
+}
+
+// mintRewardWithCapLocked mints the given block reward clamped to the cap to the
+// owner (caller must hold the lock)
+func (sst *SystemSupplyTracker) mintRewardWithCapLocked(txn interface {
+	AddBalance(types.Address, *big.Int)
+}, blockNumber uint64, ownerAddress types.Address, reward *big.Int) (MintResult, error) {
+	return sst.mintRewardsLocked(txn, rewardMint{
+		firstBlock: blockNumber,
+		block:      blockNumber,
+		blocks:     1,
+		reward:     reward,
+		split: func(minted *big.Int) []rewardPayout {
+			return []rewardPayout{{recipient: ownerAddress, amount: minted}}
+		},
+	})
+}
+
+// rewardPayout is the share of a reward mint credited to one recipient
+type rewardPayout struct {
+	recipient types.Address
+	amount    *big.Int
+}
+
+// rewardMint describes a capped reward mint for mintRewardsLocked
+type rewardMint struct {
+	// firstBlock is checked against the block order and block is the one the mint
+	// is recorded at; they differ only when several blocks are minted at once
+	firstBlock, block uint64
+	// blocks is the number of blocks the reward covers, scaling the per-block limit
+	blocks uint64
+	reward *big.Int
+	reason string
+	// split divides the minted amount among the recipients
+	split func(minted *big.Int) []rewardPayout
+	// singleEntry records one audit entry for the minted total, without a
+	// recipient, instead of one per paid recipient. The split is only credited to state.
+	singleEntry bool
+	// reserve only reserves the minted amount at block, without crediting or
+	// recording it; split is not used. The caller commits or releases the reservation.
+	reserve bool
+}
+
+// mintRewardsLocked mints a reward clamped to the cap, credits it to the
+// recipients returned by split and records one audit entry per paid recipient,
+// or a single one for the total with singleEntry.
+// Every capped reward mint goes through here so they share the same frozen, block
+// order, rate and quota checks (caller must hold the lock).
+func (sst *SystemSupplyTracker) mintRewardsLocked(txn interface {
+	AddBalance(types.Address, *big.Int)
+}, m rewardMint) (MintResult, error) {
+	result := MintResult{Minted: big.NewInt(0)}
+	blockNumber := m.block
+
+	if err := sst.tracker.checkFrozenLocked(m.reward, blockNumber); err != nil {
+		return result, err
+	}
+
+	if err := sst.tracker.checkBlockOrderLocked(m.firstBlock); err != nil {
+		return result, err
+	}
+
+	currentSupply := sst.tracker.getCurrentSupply()
+	maxSupply := getMaxSupply()
+
+	// Log current state
+	getSupplyLogger().Debug("checking reward against the supply cap", "block", blockNumber,
+		"supply", FormatAZE(currentSupply), "maxSupply", FormatAZE(maxSupply))
+
+	// If we've already reached or exceeded the max supply, do nothing.
+	if currentSupply.Cmp(maxSupply) >= 0 {
+		getSupplyLogger().Debug("supply cap reached, no reward minted", "block", blockNumber)
+		capReachedCounter.Inc()
+
+		result.CapReached = true
+
+		return result, nil
+	}
+
+	blockReward := new(big.Int).Set(m.reward)
+
+	// Check if adding the full reward would exceed the max supply.
+	newSupply := new(big.Int).Add(currentSupply, blockReward)
+	if newSupply.Cmp(maxSupply) >= 0 {
+		result.CapReached = true
+	}
+
+	if mintable := computeMintableReward(currentSupply, blockReward, maxSupply); mintable.Cmp(blockReward) < 0 {
+		// Only mint the remaining amount to hit the cap exactly.
+		blockReward = mintable
+		if blockReward.Sign() == 0 {
+			getSupplyLogger().Debug("no remaining tokens to mint", "block", blockNumber)
+			return result, nil // No remainder to mint.
+		}
+
+		capReachedCounter.Inc()
+
+		result.Partial = true
+
+		getSupplyLogger().Debug("reward clamped to the supply cap", "block", blockNumber,
+			"reward", FormatAZE(m.reward), "partial", FormatAZE(blockReward))
+	} else {
+		getSupplyLogger().Debug("minting full reward", "block", blockNumber, "reward", FormatAZE(blockReward))
+	}
+
+	if m.blocks > 1 {
+		// The per-block limit applies to the blocks as a whole, once per block covered
+		if limit := sst.tracker.maxMintPerBlock; limit != nil {
+			if total := new(big.Int).Mul(limit, new(big.Int).SetUint64(m.blocks)); blockReward.Cmp(total) > 0 {
+				return result, newSupplyError(fmt.Errorf("%w: blocks %d-%d limited to %s wei",
+					ErrMintRateExceeded, m.firstBlock, blockNumber, total.String()), blockNumber, blockReward)
+			}
+		}
+	} else if err := sst.tracker.checkMintRateLocked(blockReward, blockNumber); err != nil {
+		return result, err
+	}
+
+	if err := sst.tracker.checkMintQuotaLocked(consensusEngineCaller, blockReward, blockNumber); err != nil {
+		return result, err
+	}
+
+	if m.reserve {
+		sst.tracker.reserveLocked(blockNumber, blockReward)
+		result.Minted = new(big.Int).Set(blockReward)
+
+		return result, nil
+	}
+
+	// Now, perform the mint operation within the lock.
+	payouts := m.split(new(big.Int).Set(blockReward))
+
+	if m.singleEntry {
+		sst.tracker.appendEntryLocked(SupplyAuditLog{
+			BlockNumber: blockNumber,
+			Amount:      new(big.Int).Set(blockReward),
+			Type:        ChangeMint,
+			Timestamp:   sst.tracker.entryTimestamp(blockNumber),
+			Caller:      consensusEngineCaller,
+			Reason:      m.reason,
+		})
+	}
+
+	for _, payout := range payouts {
+		if payout.amount.Sign() == 0 {
+			continue
+		}
+
+		txn.AddBalance(payout.recipient, payout.amount)
+
+		if m.singleEntry {
+			continue
+		}
+
+		recipient := payout.recipient
+
+		sst.tracker.appendEntryLocked(SupplyAuditLog{
+			BlockNumber: blockNumber,
+			Amount:      new(big.Int).Set(payout.amount),
+			Type:        ChangeMint,
+			Timestamp:   sst.tracker.entryTimestamp(blockNumber),
+			Caller:      consensusEngineCaller,
+			Reason:      m.reason,
+			Recipient:   &recipient,
+		})
+	}
+
+	result.Minted = new(big.Int).Set(blockReward)
+
+	// Log final state
+	finalSupply := new(big.Int).Add(currentSupply, blockReward)
+	getSupplyLogger().Debug("reward minted", "block", blockNumber, "reward", FormatAZE(blockReward),
+		"supply", FormatAZE(finalSupply))
+
+	return result, nil
+}
+
+// MintBlockRewardRange mints the block rewards for every block in the inclusive
+// [fromBlock, toBlock] range in one pass, e.g. when catching up during sync.
+// The total is clamped to the supply cap exactly as minting block by block would
+// be, credited to the owner once and recorded as a single audit entry at toBlock.
+func (sst *SystemSupplyTracker) MintBlockRewardRange(
+	txn interface{ AddBalance(types.Address, *big.Int) },
+	fromBlock, toBlock uint64,
+	ownerAddress types.Address,
+) (*big.Int, error) {
+	if fromBlock > toBlock {
+		return nil, fmt.Errorf("%w: from block %d is after to block %d", ErrInvalidBlockRange, fromBlock, toBlock)
+	}
+
+	if !isMintingEnabled() {
+		return nil, newSupplyError(ErrMintingPaused, toBlock, nil)
+	}
+
+	blocks := toBlock - fromBlock + 1
+
+	result, err := sst.mintRewards(toBlock, func() (MintResult, error) {
+		return sst.mintRewardsLocked(txn, rewardMint{
+			firstBlock: fromBlock,
+			block:      toBlock,
+			blocks:     blocks,
+			reward:     new(big.Int).Mul(new(big.Int).SetUint64(blocks), big.NewInt(BlockRewardAmount)),
+			reason:     fmt.Sprintf("%s:%d-%d", ReasonBlockRewardRange, fromBlock, toBlock),
+			split: func(minted *big.Int) []rewardPayout {
+				return []rewardPayout{{recipient: ownerAddress, amount: minted}}
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Minted, nil
+}
+
+// StakedValidator is a reward recipient weighted by its staked amount
+type StakedValidator struct {
+	Address types.Address
+	Stake   *big.Int
+}
+
+// MintBlockRewardToValidators mints the cap-clamped block reward and splits it among
+// the validators proportionally to their stake, recording one audit entry for the
+// total minted; the per-validator shares are only credited to state. Rounding dust goes to the
+// highest-staked validator. When no validator has stake, the reward falls back to
+// the single owner address.
+func (sst *SystemSupplyTracker) MintBlockRewardToValidators(
+	txn interface{ AddBalance(types.Address, *big.Int) },
+	blockNumber uint64,
+	validators []StakedValidator,
+	ownerAddress types.Address,
+) (MintResult, error) {
+	if !isMintingEnabled() {
+		return MintResult{Minted: big.NewInt(0)}, newSupplyError(ErrMintingPaused, blockNumber, big.NewInt(BlockRewardAmount))
+	}
+
+	totalStake := big.NewInt(0)
+
+	for _, validator := range validators {
+		if validator.Stake == nil || validator.Stake.Sign() < 0 {
+			return MintResult{Minted: big.NewInt(0)}, fmt.Errorf("%w: stake of validator %s",
+				ErrInvalidAmount, validator.Address)
+		}
+
+		totalStake.Add(totalStake, validator.Stake)
+	}
+
+	if totalStake.Sign() == 0 {
+		return sst.MintRewardWithCap(txn, blockNumber, ownerAddress)
+	}
+
+	result, err := sst.mintRewards(blockNumber, func() (MintResult, error) {
+		return sst.mintRewardsLocked(txn, rewardMint{
+			firstBlock: blockNumber,
+			block:      blockNumber,
+			blocks:     1,
+			reward:     big.NewInt(BlockRewardAmount),
+			split: func(minted *big.Int) []rewardPayout {
+				return stakePayouts(minted, validators, totalStake)
+			},
+			singleEntry: true,
+		})
+	})
+
+	if err == nil && result.Minted.Sign() > 0 {
+		getSupplyLogger().Debug("block reward split among validators", "block", blockNumber,
+			"reward", FormatAZE(result.Minted), "validators", len(validators))
+	}
+
+	return result, err
+}
+
+// stakePayouts splits amount among the validators like splitByStake and returns
+// the shares as reward payouts
+func stakePayouts(amount *big.Int, validators []StakedValidator, totalStake *big.Int) []rewardPayout {
+	shares := splitByStake(amount, validators, totalStake)
+	payouts := make([]rewardPayout, len(validators))
+
+	for i, validator := range validators {
+		payouts[i] = rewardPayout{recipient: validator.Address, amount: shares[i]}
+	}
+
+	return payouts
+}
+
+// splitByStake splits amount among the validators proportionally to their stake,
+// assigning the rounding dust to the highest-staked validator (the first one on
+// a tie). totalStake must be the positive sum of the validators' stakes.
+func splitByStake(amount *big.Int, validators []StakedValidator, totalStake *big.Int) []*big.Int {
+	payouts := make([]*big.Int, len(validators))
+	distributed := big.NewInt(0)
+	topIndex := 0
+
+	for i, validator := range validators {
+		payouts[i] = new(big.Int).Mul(amount, validator.Stake)
+		payouts[i].Div(payouts[i], totalStake)
+		distributed.Add(distributed, payouts[i])
+
+		if validator.Stake.Cmp(validators[topIndex].Stake) > 0 {
+			topIndex = i
+		}
+	}
+
+	payouts[topIndex].Add(payouts[topIndex], new(big.Int).Sub(amount, distributed))
+
+	return payouts
+}
+
+// ReconcileSupply compares the audit-log supply against the deterministic formula
+// genesisTotal + blockNumber*reward (clamped to the max supply) and returns an error
+// when they diverge. It only reads state and is safe to call periodically.
+func (sst *SystemSupplyTracker) ReconcileSupply(blockNumber uint64) error {
+	trackedSupply := sst.tracker.GetTotalSupply()
+
+	expectedSupply := getCurrentSupplyFromBlockNumber(blockNumber)
+	if maxSupply := getMaxSupply(); expectedSupply.Cmp(maxSupply) > 0 {
+		expectedSupply = maxSupply
+	}
+
+	delta := new(big.Int).Sub(trackedSupply, expectedSupply)
+	if delta.Sign() == 0 {
+		return nil
+	}
+
+	getSupplyLogger().Warn("audit log supply differs from the formula", "block", blockNumber,
+		"delta", delta.String())
+
+	return fmt.Errorf("%w at block %d: audit log %s wei, formula %s wei, delta %s wei",
+		ErrSupplyMismatch, blockNumber, trackedSupply.String(), expectedSupply.String(), delta.String())
+}
+
+// ReconcileGenesisChange records an edit of the genesis allocation made between
+// restarts, so the shift in supply shows up in the audit trail instead of being
+// hidden in the baseline. The difference newTotal - oldTotal is recorded as a
+// mint or burn at block 0 by GenesisAdjustmentCaller, placed after any existing
+// block 0 entries so the log stays ordered by block. Adjustments that would take
+// the supply below zero or the current supply above the cap are rejected.
+func (sst *SystemSupplyTracker) ReconcileGenesisChange(oldTotal, newTotal *big.Int) error {
+	if oldTotal == nil || newTotal == nil || oldTotal.Sign() < 0 || newTotal.Sign() < 0 {
+		return fmt.Errorf("%w: genesis totals must be non-negative", ErrInvalidAmount)
+	}
+
+	delta := new(big.Int).Sub(newTotal, oldTotal)
+	if delta.Sign() == 0 {
+		return nil
+	}
+
+	st := sst.tracker
+
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	entry := SupplyAuditLog{
+		BlockNumber: 0,
+		Amount:      new(big.Int).Abs(delta),
+		Type:        ChangeMint,
+		Timestamp:   st.entryTimestamp(0),
+		Caller:      GenesisAdjustmentCaller,
+	}
+
+	if err := st.checkFrozenLocked(entry.Amount, 0); err != nil {
+		return err
+	}
+
+	newSupply := new(big.Int).Add(st.getCurrentSupply(), delta)
+
+	if delta.Sign() < 0 {
+		entry.Type = ChangeBurn
+
+		// The burn is applied right after the initial supply
+		if st.initialSupply.Cmp(entry.Amount) < 0 || newSupply.Sign() < 0 {
+			return newSupplyError(ErrInsufficientSupply, 0, entry.Amount)
+		}
+	} else if newSupply.Cmp(getMaxSupply()) > 0 {
+		return newSupplyError(ErrSupplyCapExceeded, 0, entry.Amount)
+	}
+
+	i := 0
+	for i < len(st.auditLog) && st.auditLog[i].BlockNumber == 0 {
+		i++
+	}
+
+	st.insertEntryLocked(i, entry)
+
+	getSupplyLogger().Warn("genesis total changed, recorded a correction", "old", FormatAZE(oldTotal),
+		"new", FormatAZE(newTotal), "type", entry.Type, "amount", FormatAZE(entry.Amount))
+
+	return nil
+}
+
+// ValidatorRewardHistory returns the reward mints credited to the given validator
+// within the inclusive [fromBlock, toBlock] range
+func (sst *SystemSupplyTracker) ValidatorRewardHistory(v types.Address, fromBlock, toBlock uint64) []SupplyAuditLog {
+	sst.tracker.mutex.RLock()
+	defer sst.tracker.mutex.RUnlock()
+
+	history := make([]SupplyAuditLog, 0)
+
+	for _, change := range sst.tracker.auditLog {
+		if change.Type != ChangeMint || change.Recipient == nil || *change.Recipient != v {
+			continue
+		}
+
+		if change.BlockNumber < fromBlock || change.BlockNumber > toBlock {
+			continue
+		}
+
+		history = append(history, copyAuditEntry(change))
+	}
+
+	return history
+}
+
+// GetRewardsByRecipient returns the total reward minted to the given address
+// over the whole audit log
+func (sst *SystemSupplyTracker) GetRewardsByRecipient(addr types.Address) *big.Int {
+	sst.tracker.mutex.RLock()
+	defer sst.tracker.mutex.RUnlock()
+
+	total := big.NewInt(0)
+
+	for _, change := range sst.tracker.auditLog {
+		if change.Type == ChangeMint && change.Recipient != nil && *change.Recipient == addr {
+			total.Add(total, change.Amount)
+		}
+	}
+
+	return total
+}
+
+// GetCurrentSupply gets the current total supply
+func (sst *SystemSupplyTracker) GetCurrentSupply() *big.Int {
+	return sst.tracker.GetTotalSupply()
+}
+
+// GetLastAuditEntry returns a copy of the most recent audit entry,
+// and false when the log is empty
+func (sst *SystemSupplyTracker) GetLastAuditEntry() (SupplyAuditLog, bool) {
+	return sst.tracker.GetLastAuditEntry()
+}
+
+// LastMintBlock returns the block of the most recent mint, or 0 when none is recorded
+func (sst *SystemSupplyTracker) LastMintBlock() uint64 {
+	return sst.tracker.LastMintBlock()
+}
+
+// BlocksSinceLastMint returns how many blocks passed between the last mint and currentBlock
+func (sst *SystemSupplyTracker) BlocksSinceLastMint(currentBlock uint64) uint64 {
+	return sst.tracker.BlocksSinceLastMint(currentBlock)
+}
+
+// Stats returns a consistent snapshot of the tracker state
+func (sst *SystemSupplyTracker) Stats() SupplyStats {
+	return sst.tracker.Stats()
+}
+
+// AuditLogLen returns the number of audit entries
+func (sst *SystemSupplyTracker) AuditLogLen() int {
+	return sst.tracker.AuditLogLen()
+}
+
+// RemainingMintableSupply returns how much can still be minted before the max
+// supply is reached, or zero once the cap is reached or exceeded
+func (sst *SystemSupplyTracker) RemainingMintableSupply() *big.Int {
+	return remainingMintable(sst.tracker.GetTotalSupply(), getMaxSupply())
+}
+
+// RemainingMintableSupplyAZE returns RemainingMintableSupply rendered in tokens
+func (sst *SystemSupplyTracker) RemainingMintableSupplyAZE() string {
+	return FormatAZE(sst.RemainingMintableSupply())
+}
+
+// SupplyPercentOfCap returns the current supply as a percentage of the max supply,
+// clamped to 100
+func (sst *SystemSupplyTracker) SupplyPercentOfCap() float64 {
+	return percentOfCap(sst.tracker.GetTotalSupply(), getMaxSupply())
+}
+
+// percentOfCap returns supply / maxSupply * 100 clamped to [0, 100], computed
+// with big.Float so wei-scale amounts keep their precision until the result
+func percentOfCap(supply, maxSupply *big.Int) float64 {
+	if supply.Cmp(maxSupply) >= 0 {
+		return 100
+	}
+
+	if supply.Sign() <= 0 {
+		return 0
+	}
+
+	ratio := new(big.Float).SetPrec(256).SetInt(supply)
+	ratio.Quo(ratio, new(big.Float).SetPrec(256).SetInt(maxSupply))
+	percent, _ := ratio.Mul(ratio, big.NewFloat(100)).Float64()
+
+	return percent
+}
+
+// remainingMintable returns maxSupply - supply, clamped at zero
+func remainingMintable(supply, maxSupply *big.Int) *big.Int {
+	remaining := new(big.Int).Sub(maxSupply, supply)
+	if remaining.Sign() < 0 {
+		return big.NewInt(0)
+	}
+
+	return remaining
+}
+
+// GetAuditLog gets the supply audit log
 func (sst *SystemSupplyTracker) GetAuditLog() []SupplyAuditLog {
 	return sst.tracker.GetAuditLog()
 }
+
+// GetAuditLogPaginated returns a window of the supply audit log and the total
+// number of entries; see SupplyTracker.GetAuditLogPaginated
+func (sst *SystemSupplyTracker) GetAuditLogPaginated(offset, limit int) ([]SupplyAuditLog, int) {
+	return sst.tracker.GetAuditLogPaginated(offset, limit)
+}
+
+// EstimatedMemoryBytes approximates the memory footprint of the audit log.
+// Each entry is counted as the struct size plus the big.Int magnitude bytes
+// and the caller string length.
+func (st *SupplyTracker) EstimatedMemoryBytes() int {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	entrySize := int(unsafe.Sizeof(SupplyAuditLog{}))
+	bigIntSize := int(unsafe.Sizeof(big.Int{}))
+
+	total := cap(st.auditLog) * entrySize
+	for _, change := range st.auditLog {
+		if change.Amount != nil {
+			total += bigIntSize + len(change.Amount.Bytes())
+		}
+
+		if change.Recipient != nil {
+			total += types.AddressLength
+		}
+
+		total += len(change.Caller) + len(change.Type) + len(change.Reason)
+	}
+
+	return total
+}
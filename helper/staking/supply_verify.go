@@ -0,0 +1,182 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var ErrInconsistentAuditLog = errors.New("inconsistent audit log")
+
+// AuditLogError reports the first audit entry that failed verification
+type AuditLogError struct {
+	// Index is the position of the offending entry in the log
+	Index  int
+	Reason string
+}
+
+func (e *AuditLogError) Error() string {
+	return fmt.Sprintf("%v: entry %d: %s", ErrInconsistentAuditLog, e.Index, e.Reason)
+}
+
+func (e *AuditLogError) Unwrap() error {
+	return ErrInconsistentAuditLog
+}
+
+// VerifyAuditLogConsistency replays an audit log from the initial supply and checks
+// that block numbers never decrease, that every entry is a positive mint or burn,
+// and that the supply never drops below zero or rises above maxSupply. It does not
+// touch any tracker, so a dumped log can be validated without a running node.
+// The returned error is an *AuditLogError for the first offending entry.
+func VerifyAuditLogConsistency(log []SupplyAuditLog, initialSupply, maxSupply *big.Int) error {
+	if initialSupply == nil || initialSupply.Sign() < 0 {
+		return fmt.Errorf("%w: initial supply must not be negative", ErrInvalidAmount)
+	}
+
+	if maxSupply == nil || maxSupply.Cmp(initialSupply) < 0 {
+		return fmt.Errorf("%w: max supply must not be below the initial supply", ErrInvalidMaxSupply)
+	}
+
+	supply := new(big.Int).Set(initialSupply)
+
+	for i, entry := range log {
+		if i > 0 && entry.BlockNumber < log[i-1].BlockNumber {
+			return &AuditLogError{
+				Index:  i,
+				Reason: fmt.Sprintf("block %d is below previous block %d", entry.BlockNumber, log[i-1].BlockNumber),
+			}
+		}
+
+		if entry.Amount == nil || entry.Amount.Sign() <= 0 {
+			return &AuditLogError{Index: i, Reason: "amount must be positive"}
+		}
+
+		switch entry.Type {
+		case ChangeMint:
+			supply.Add(supply, entry.Amount)
+		case ChangeBurn:
+			supply.Sub(supply, entry.Amount)
+		default:
+			return &AuditLogError{Index: i, Reason: fmt.Sprintf("unknown type %q", entry.Type)}
+		}
+
+		if supply.Sign() < 0 {
+			return &AuditLogError{
+				Index:  i,
+				Reason: fmt.Sprintf("supply drops below zero at block %d", entry.BlockNumber),
+			}
+		}
+
+		if supply.Cmp(maxSupply) > 0 {
+			return &AuditLogError{
+				Index: i,
+				Reason: fmt.Sprintf("supply %s wei exceeds max supply %s wei at block %d",
+					supply.String(), maxSupply.String(), entry.BlockNumber),
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateSupplyInvariantsAtBoot checks the global supply state on node startup
+// and returns the first violated invariant, so a node with corrupt state fails
+// fast instead of running with a wrong supply:
+//
+//   - the cached genesis total equals the sum of the genesis allocation
+//   - neither the genesis total nor the audit-log supply exceeds the max supply
+//   - the audit-log supply at currentBlock equals the deterministic supply (clamped
+//     to the max supply), minus at most the burns recorded after genesis
+//
+// The last check needs an audit log covering every block up to currentBlock. A
+// log started at boot, or compacted into a snapshot, does not, so the check is
+// skipped for it.
+func ValidateSupplyInvariantsAtBoot(currentBlock uint64) error {
+	if GetGenesisAllocCache() == nil {
+		return ErrGenesisNotLoaded
+	}
+
+	genesisTotal := getGenesisTotal()
+	if allocSum := calculateGenesisTotal(); genesisTotal.Cmp(allocSum) != 0 {
+		return fmt.Errorf("%w: cached genesis total %s wei, genesis allocation sum %s wei",
+			ErrSupplyMismatch, genesisTotal.String(), allocSum.String())
+	}
+
+	tracker := GetGlobalSupplyTracker().tracker
+	stats := tracker.Stats()
+
+	if genesisTotal.Cmp(stats.MaxSupply) > 0 {
+		return fmt.Errorf("%w: genesis total %s wei is above max supply %s wei",
+			ErrSupplyCapExceeded, genesisTotal.String(), stats.MaxSupply.String())
+	}
+
+	if stats.CurrentSupply.Cmp(stats.MaxSupply) > 0 {
+		return fmt.Errorf("%w: audit log supply %s wei is above max supply %s wei",
+			ErrSupplyCapExceeded, stats.CurrentSupply.String(), stats.MaxSupply.String())
+	}
+
+	trackedSupply, burned, covered := tracker.supplyThroughBlock(currentBlock)
+	if !covered {
+		getSupplyLogger().Warn("audit log does not cover the chain, skipping the supply formula check",
+			"block", currentBlock, "entries", stats.AuditEntryCount)
+
+		return nil
+	}
+
+	expectedSupply := getCurrentSupplyFromBlockNumber(currentBlock)
+	if expectedSupply.Cmp(stats.MaxSupply) > 0 {
+		expectedSupply = stats.MaxSupply
+	}
+
+	// The formula already accounts for genesis burns, later burns only ever take
+	// the tracked supply below it
+	shortfall := new(big.Int).Sub(expectedSupply, trackedSupply)
+	if shortfall.Sign() < 0 || shortfall.Cmp(burned) > 0 {
+		return fmt.Errorf("%w at block %d: audit log %s wei, formula %s wei, recorded burns %s wei",
+			ErrSupplyMismatch, currentBlock, trackedSupply.String(), expectedSupply.String(), burned.String())
+	}
+
+	return nil
+}
+
+// supplyThroughBlock returns the audit-log supply after blockNumber and the amount
+// burned in blocks 1 through blockNumber. covered is false unless the log records
+// every block from 1 through blockNumber, i.e. it has no snapshot, starts with a
+// block 1 entry and reaches blockNumber; block 0 is always covered.
+func (st *SupplyTracker) supplyThroughBlock(blockNumber uint64) (*big.Int, *big.Int, bool) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	supply := new(big.Int).Set(st.initialSupply)
+	burned := big.NewInt(0)
+
+	firstBlock, lastBlock := uint64(0), uint64(0)
+
+	for _, entry := range st.auditLog {
+		if entry.BlockNumber > 0 && firstBlock == 0 {
+			firstBlock = entry.BlockNumber
+		}
+
+		lastBlock = max(lastBlock, entry.BlockNumber)
+
+		if entry.BlockNumber > blockNumber {
+			continue
+		}
+
+		switch entry.Type {
+		case ChangeMint:
+			supply.Add(supply, entry.Amount)
+		case ChangeBurn:
+			supply.Sub(supply, entry.Amount)
+
+			if entry.BlockNumber > 0 {
+				burned.Add(burned, entry.Amount)
+			}
+		}
+	}
+
+	covered := blockNumber == 0 ||
+		(st.snapshot == nil && firstBlock == 1 && lastBlock >= blockNumber)
+
+	return supply, burned, covered
+}
@@ -0,0 +1,132 @@
+package staking
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestVerifyAuditLogConsistency(t *testing.T) {
+	maxSupply := big.NewInt(100)
+	entry := func(block uint64, amount int64, changeType SupplyChangeType) SupplyAuditLog {
+		return SupplyAuditLog{BlockNumber: block, Amount: big.NewInt(amount), Type: changeType}
+	}
+
+	valid := []SupplyAuditLog{
+		entry(1, 40, ChangeMint),
+		entry(1, 10, ChangeBurn),
+		entry(3, 50, ChangeMint),
+	}
+
+	if err := VerifyAuditLogConsistency(valid, big.NewInt(20), maxSupply); err != nil {
+		t.Fatalf("Expected valid log to verify: %v", err)
+	}
+
+	cases := []struct {
+		name  string
+		log   []SupplyAuditLog
+		index int
+	}{
+		{"non-monotonic", []SupplyAuditLog{entry(2, 1, ChangeMint), entry(1, 1, ChangeMint)}, 1},
+		{"negative supply", []SupplyAuditLog{entry(1, 5, ChangeMint), entry(2, 30, ChangeBurn)}, 1},
+		{"above max", []SupplyAuditLog{entry(1, 80, ChangeMint), entry(2, 1, ChangeMint)}, 1},
+		{"invalid amount", []SupplyAuditLog{entry(1, 0, ChangeMint)}, 0},
+		{"unknown type", []SupplyAuditLog{entry(1, 1, ChangeMint), entry(1, 1, "mystery")}, 1},
+	}
+
+	for _, c := range cases {
+		err := VerifyAuditLogConsistency(c.log, big.NewInt(20), maxSupply)
+		if !errors.Is(err, ErrInconsistentAuditLog) {
+			t.Errorf("%s: expected ErrInconsistentAuditLog, got %v", c.name, err)
+
+			continue
+		}
+
+		var logErr *AuditLogError
+		if !errors.As(err, &logErr) || logErr.Index != c.index {
+			t.Errorf("%s: expected offending index %d, got %v", c.name, c.index, err)
+		}
+	}
+
+	if err := VerifyAuditLogConsistency(nil, big.NewInt(200), maxSupply); !errors.Is(err, ErrInvalidMaxSupply) {
+		t.Errorf("Expected ErrInvalidMaxSupply, got %v", err)
+	}
+}
+
+func TestValidateSupplyInvariantsAtBoot(t *testing.T) {
+	defer func() {
+		SetGenesisAllocCache(nil)
+		_ = SetGenesisBurns(nil)
+		InitializeSupplyTracker(big.NewInt(0))
+	}()
+
+	SetGenesisAllocCache(nil)
+
+	if err := ValidateSupplyInvariantsAtBoot(0); !errors.Is(err, ErrGenesisNotLoaded) {
+		t.Fatalf("Expected ErrGenesisNotLoaded, got %v", err)
+	}
+
+	reward := big.NewInt(BlockRewardAmount)
+	account := &chain.GenesisAccount{Balance: new(big.Int).Mul(big.NewInt(10), reward)}
+
+	SetGenesisAllocCache(map[types.Address]*chain.GenesisAccount{
+		types.StringToAddress("0x1"): account,
+	})
+
+	if err := SetGenesisBurns([]GenesisBurn{{Amount: reward, Reason: "treasury"}}); err != nil {
+		t.Fatalf("Failed to set genesis burns: %v", err)
+	}
+
+	if err := InitializeFromGenesis(); err != nil {
+		t.Fatalf("Failed to initialize from genesis: %v", err)
+	}
+
+	if err := ValidateSupplyInvariantsAtBoot(0); err != nil {
+		t.Fatalf("Expected invariants to hold at genesis, got %v", err)
+	}
+
+	// A log started at boot does not cover the blocks already on the chain
+	if err := ValidateSupplyInvariantsAtBoot(3); err != nil {
+		t.Fatalf("Expected the formula check to be skipped, got %v", err)
+	}
+
+	sst := GetGlobalSupplyTracker()
+
+	for block := uint64(1); block <= 3; block++ {
+		if err := sst.MintBlockReward(reward, block); err != nil {
+			t.Fatalf("Failed to mint: %v", err)
+		}
+	}
+
+	if err := ValidateSupplyInvariantsAtBoot(3); err != nil {
+		t.Fatalf("Expected invariants to hold, got %v", err)
+	}
+
+	// A recorded burn is tolerated
+	if err := sst.tracker.Burn(big.NewInt(5), 3, "consensus_engine"); err != nil {
+		t.Fatalf("Failed to burn: %v", err)
+	}
+
+	if err := ValidateSupplyInvariantsAtBoot(3); err != nil {
+		t.Errorf("Expected burns to be tolerated, got %v", err)
+	}
+
+	// A short reward is not covered by the burns, the genesis burn included
+	if err := sst.tracker.Mint(big.NewInt(1), 4, "consensus_engine"); err != nil {
+		t.Fatalf("Failed to mint: %v", err)
+	}
+
+	if err := ValidateSupplyInvariantsAtBoot(4); !errors.Is(err, ErrSupplyMismatch) {
+		t.Errorf("Expected ErrSupplyMismatch for a short reward, got %v", err)
+	}
+
+	// Editing the allocation behind the cache is detected
+	account.Balance = new(big.Int).Mul(big.NewInt(11), reward)
+
+	if err := ValidateSupplyInvariantsAtBoot(3); !errors.Is(err, ErrSupplyMismatch) {
+		t.Errorf("Expected ErrSupplyMismatch for a stale genesis total, got %v", err)
+	}
+}
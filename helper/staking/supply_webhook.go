@@ -0,0 +1,196 @@
+package staking
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+var ErrInvalidWebhookURL = errors.New("invalid webhook url")
+
+const (
+	// webhookQueueSize is the number of entries waiting for delivery before new
+	// entries are dropped
+	webhookQueueSize = 256
+	// webhookWorkers is the number of concurrent deliveries
+	webhookWorkers = 2
+	// webhookMaxAttempts is the number of delivery attempts per entry
+	webhookMaxAttempts = 4
+	// defaultWebhookTimeout is the request timeout used when none is configured
+	defaultWebhookTimeout = 5 * time.Second
+)
+
+// webhookBaseBackoff is the delay before the first retry, doubled on every
+// following retry
+var webhookBaseBackoff = 500 * time.Millisecond
+
+// WebhookConfig configures the endpoint supply audit entries are posted to
+type WebhookConfig struct {
+	// URL is the http(s) endpoint receiving the JSON-encoded SupplyAuditLog
+	URL string
+	// Timeout bounds every delivery attempt, defaultWebhookTimeout when zero
+	Timeout time.Duration
+}
+
+var (
+	supplyWebhook     *webhookDispatcher
+	supplyWebhookLock sync.RWMutex
+)
+
+// SetSupplyWebhook posts every audit entry recorded from now on to cfg.URL as
+// JSON. Delivery is asynchronous through a bounded queue served by a fixed
+// number of workers, so block processing never waits on the endpoint: failed
+// posts are logged and retried with exponential backoff up to webhookMaxAttempts
+// times, and entries are dropped while the queue is full. An empty URL disables
+// the webhook. Entries still queued for a replaced webhook are discarded.
+func SetSupplyWebhook(cfg WebhookConfig) error {
+	if cfg.URL != "" {
+		u, err := url.Parse(cfg.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %q", ErrInvalidWebhookURL, cfg.URL)
+		}
+	}
+
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultWebhookTimeout
+	}
+
+	supplyWebhookLock.Lock()
+	defer supplyWebhookLock.Unlock()
+
+	if supplyWebhook != nil {
+		supplyWebhook.close()
+		supplyWebhook = nil
+	}
+
+	if cfg.URL != "" {
+		supplyWebhook = newWebhookDispatcher(cfg)
+	}
+
+	return nil
+}
+
+// notifySupplyWebhook queues the entry for the configured webhook, if any
+func notifySupplyWebhook(entry SupplyAuditLog) {
+	supplyWebhookLock.RLock()
+	defer supplyWebhookLock.RUnlock()
+
+	if supplyWebhook != nil {
+		supplyWebhook.enqueue(copyAuditEntry(entry))
+	}
+}
+
+// webhookDispatcher delivers audit entries to a webhook from a bounded queue
+type webhookDispatcher struct {
+	cfg    WebhookConfig
+	client *http.Client
+	queue  chan SupplyAuditLog
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newWebhookDispatcher(cfg WebhookConfig) *webhookDispatcher {
+	d := &webhookDispatcher{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+		queue:  make(chan SupplyAuditLog, webhookQueueSize),
+		done:   make(chan struct{}),
+	}
+
+	for i := 0; i < webhookWorkers; i++ {
+		go d.run()
+	}
+
+	return d
+}
+
+// enqueue queues the entry without blocking, dropping it when the queue is full
+func (d *webhookDispatcher) enqueue(entry SupplyAuditLog) {
+	select {
+	case d.queue <- entry:
+	default:
+		getSupplyLogger().Warn("supply webhook queue full, dropping entry", "type", entry.Type, "block", entry.BlockNumber)
+	}
+}
+
+// close stops the workers; in-flight deliveries are abandoned at their next retry
+func (d *webhookDispatcher) close() {
+	d.once.Do(func() {
+		close(d.done)
+	})
+}
+
+func (d *webhookDispatcher) run() {
+	for {
+		select {
+		case <-d.done:
+			return
+		case entry := <-d.queue:
+			d.deliver(entry)
+		}
+	}
+}
+
+// deliver posts the entry, retrying failures with exponential backoff
+func (d *webhookDispatcher) deliver(entry SupplyAuditLog) {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		getSupplyLogger().Warn("failed to encode supply webhook entry", "block", entry.BlockNumber, "err", err)
+
+		return
+	}
+
+	backoff := webhookBaseBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := d.post(body)
+		if err == nil {
+			return
+		}
+
+		getSupplyLogger().Warn("supply webhook delivery failed", "attempt", attempt,
+			"maxAttempts", webhookMaxAttempts, "block", entry.BlockNumber, "err", err)
+
+		if attempt == webhookMaxAttempts {
+			return
+		}
+
+		select {
+		case <-d.done:
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+func (d *webhookDispatcher) post(body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), d.cfg.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return nil
+}
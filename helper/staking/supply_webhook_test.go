@@ -0,0 +1,76 @@
+package staking
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSupplyWebhookRetriesDelivery(t *testing.T) {
+	defaultBackoff := webhookBaseBackoff
+	defer func() {
+		if err := SetSupplyWebhook(WebhookConfig{}); err != nil {
+			t.Fatalf("Failed to disable webhook: %v", err)
+		}
+
+		webhookBaseBackoff = defaultBackoff
+	}()
+
+	webhookBaseBackoff = time.Millisecond
+
+	var attempts int32
+
+	received := make(chan map[string]interface{}, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first attempt to exercise the retry
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+
+		received <- payload
+	}))
+	defer server.Close()
+
+	if err := SetSupplyWebhook(WebhookConfig{URL: server.URL, Timeout: time.Second}); err != nil {
+		t.Fatalf("Failed to set webhook: %v", err)
+	}
+
+	tracker := NewSupplyTracker(big.NewInt(0))
+	if err := tracker.Mint(big.NewInt(42), 7, "consensus_engine"); err != nil {
+		t.Fatalf("Failed to mint: %v", err)
+	}
+
+	select {
+	case payload := <-received:
+		if payload["blockNumber"] != float64(7) || payload["amount"] != "42" || payload["type"] != "mint" {
+			t.Errorf("Unexpected payload: %v", payload)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the webhook delivery")
+	}
+
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("Expected 2 delivery attempts, got %d", got)
+	}
+}
+
+func TestSetSupplyWebhookRejectsInvalidURL(t *testing.T) {
+	for _, raw := range []string{"localhost:8080", "ftp://example.com", "http://"} {
+		if err := SetSupplyWebhook(WebhookConfig{URL: raw}); !errors.Is(err, ErrInvalidWebhookURL) {
+			t.Errorf("Expected ErrInvalidWebhookURL for %q, got %v", raw, err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var ErrInvalidAZEAmount = errors.New("invalid AZE amount")
+
+// tokenDecimals returns the configured number of token decimals
+func tokenDecimals() int {
+	tokenDecimalsLock.RLock()
+	defer tokenDecimalsLock.RUnlock()
+
+	return int(TokenDecimals)
+}
+
+// tokenScale returns 10^decimals, the number of wei in one token
+func tokenScale(decimals int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+}
+
+// FormatAZE renders a wei amount in AZE using TokenDecimals, exactly and without
+// trailing fractional zeros, e.g. 1500000000000000000 wei as "1.5"
+func FormatAZE(wei *big.Int) string {
+	decimals := tokenDecimals()
+
+	whole, frac := new(big.Int).QuoRem(new(big.Int).Abs(wei), tokenScale(decimals), new(big.Int))
+
+	sign := ""
+	if wei.Sign() < 0 {
+		sign = "-"
+	}
+
+	if frac.Sign() == 0 {
+		return sign + whole.String()
+	}
+
+	fracText := strings.TrimRight(fmt.Sprintf("%0*s", decimals, frac.String()), "0")
+
+	return sign + whole.String() + "." + fracText
+}
+
+// ParseAZE parses a decimal AZE amount such as "1.5" into wei using TokenDecimals.
+// An optional leading minus sign is accepted; anything else but digits and a single
+// decimal point, or more fractional digits than TokenDecimals, is rejected.
+func ParseAZE(aze string) (*big.Int, error) {
+	decimals := tokenDecimals()
+
+	text := strings.TrimPrefix(aze, "-")
+	negative := len(text) != len(aze)
+
+	whole, frac, hasPoint := strings.Cut(text, ".")
+	if whole == "" || (hasPoint && frac == "") || !isDigits(whole) || !isDigits(frac) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAZEAmount, aze)
+	}
+
+	if len(frac) > decimals {
+		return nil, fmt.Errorf("%w: %q has more than %d decimals", ErrInvalidAZEAmount, aze, decimals)
+	}
+
+	wei, ok := new(big.Int).SetString(whole+frac+strings.Repeat("0", decimals-len(frac)), 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAZEAmount, aze)
+	}
+
+	if negative {
+		wei.Neg(wei)
+	}
+
+	return wei, nil
+}
+
+// isDigits reports whether s only contains ASCII digits
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
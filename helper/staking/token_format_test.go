@@ -0,0 +1,56 @@
+package staking
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestFormatAZE(t *testing.T) {
+	maxSupply, _ := new(big.Int).SetString("1000000000000000000000000001", 10)
+
+	cases := []struct {
+		wei      *big.Int
+		expected string
+	}{
+		{big.NewInt(0), "0"},
+		{big.NewInt(BlockRewardAmount), "1"},
+		{big.NewInt(1500000000000000000), "1.5"},
+		{big.NewInt(1), "0.000000000000000001"},
+		{big.NewInt(-2500000000000000000), "-2.5"},
+		// Beyond float64 precision
+		{maxSupply, "1000000000.000000000000000001"},
+	}
+
+	for _, c := range cases {
+		if got := FormatAZE(c.wei); got != c.expected {
+			t.Errorf("FormatAZE(%s): expected %s, got %s", c.wei.String(), c.expected, got)
+		}
+
+		parsed, err := ParseAZE(c.expected)
+		if err != nil || parsed.Cmp(c.wei) != 0 {
+			t.Errorf("ParseAZE(%s): expected %s, got %v, %v", c.expected, c.wei.String(), parsed, err)
+		}
+	}
+}
+
+func TestParseAZEInvalid(t *testing.T) {
+	for _, input := range []string{"", "-", ".", "1.", ".5", "1.2.3", "1e18", "+1", " 1", "0x10", "1.0000000000000000001"} {
+		if _, err := ParseAZE(input); !errors.Is(err, ErrInvalidAZEAmount) {
+			t.Errorf("ParseAZE(%q): expected ErrInvalidAZEAmount, got %v", input, err)
+		}
+	}
+}
+
+func TestFormatAZETokenDecimals(t *testing.T) {
+	SetTokenDecimals(6)
+	defer SetTokenDecimals(18)
+
+	if got := FormatAZE(big.NewInt(1250000)); got != "1.25" {
+		t.Errorf("Expected 1.25 with 6 decimals, got %s", got)
+	}
+
+	if got, err := ParseAZE("3.000001"); err != nil || got.Int64() != 3000001 {
+		t.Errorf("Expected 3000001 with 6 decimals, got %v, %v", got, err)
+	}
+}
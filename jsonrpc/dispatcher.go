@@ -39,6 +39,7 @@ type endpoints struct {
 	TxPool *TxPool
 	Bridge *Bridge
 	Debug  *Debug
+	Supply *Supply
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -111,6 +112,9 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 		store,
 	}
 	d.endpoints.Debug = NewDebug(store, d.params.concurrentRequestsDebug)
+	d.endpoints.Supply = &Supply{
+		stakingSupplyStore{},
+	}
 
 	var err error
 
@@ -134,7 +138,11 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 		return err
 	}
 
-	return d.registerService("debug", d.endpoints.Debug)
+	if err = d.registerService("debug", d.endpoints.Debug); err != nil {
+		return err
+	}
+
+	return d.registerService("supply", d.endpoints.Supply)
 }
 
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
@@ -0,0 +1,124 @@
+package jsonrpc
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/helper/staking"
+)
+
+// supplyStore interface provides access to the methods needed by supply endpoint
+type supplyStore interface {
+	GetCurrentSupply() *big.Int
+	GetMaxSupply() *big.Int
+	GetSupplyAuditLog() []staking.SupplyAuditLog
+	ProjectSupplyAt(blockNumber uint64) staking.ProjectedSupply
+	CurrentEmissionRate(blockNumber uint64) *big.Int
+}
+
+// stakingSupplyStore is the supplyStore backed by the global supply tracker
+type stakingSupplyStore struct{}
+
+func (stakingSupplyStore) GetCurrentSupply() *big.Int {
+	return staking.GetCurrentSupply()
+}
+
+func (stakingSupplyStore) GetMaxSupply() *big.Int {
+	return staking.GetMaxSupply()
+}
+
+func (stakingSupplyStore) GetSupplyAuditLog() []staking.SupplyAuditLog {
+	return staking.GetSupplyAuditLog()
+}
+
+func (stakingSupplyStore) ProjectSupplyAt(blockNumber uint64) staking.ProjectedSupply {
+	return staking.ProjectSupplyAt(blockNumber)
+}
+
+func (stakingSupplyStore) CurrentEmissionRate(blockNumber uint64) *big.Int {
+	return staking.CurrentEmissionRate(blockNumber)
+}
+
+// Supply is the supply jsonrpc endpoint
+type Supply struct {
+	store supplyStore
+}
+
+// supplyAuditLogEntry is the JSON-RPC representation of a supply audit log entry
+type supplyAuditLogEntry struct {
+	BlockNumber argUint64 `json:"blockNumber"`
+	Amount      argBig    `json:"amount"`
+	Type        string    `json:"type"`
+	Timestamp   argUint64 `json:"timestamp"`
+	Caller      string    `json:"caller"`
+}
+
+// projectedSupply is the JSON-RPC representation of a supply projection
+type projectedSupply struct {
+	BlockNumber argUint64  `json:"blockNumber"`
+	Supply      argBig     `json:"supply"`
+	SupplyAZE   string     `json:"supplyAZE"`
+	PastCap     bool       `json:"pastCap"`
+	CapBlock    *argUint64 `json:"capBlock,omitempty"`
+}
+
+// GetTotalSupply returns the current total supply in wei
+func (s *Supply) GetTotalSupply() (interface{}, error) {
+	return argBigPtr(s.store.GetCurrentSupply()), nil
+}
+
+// GetMaxSupply returns the maximum supply in wei
+func (s *Supply) GetMaxSupply() (interface{}, error) {
+	return argBigPtr(s.store.GetMaxSupply()), nil
+}
+
+// GetAuditLog returns the supply audit log entries,
+// optionally restricted to the inclusive [from, to] block range
+func (s *Supply) GetAuditLog(from *argUint64, to *argUint64) (interface{}, error) {
+	auditLog := s.store.GetSupplyAuditLog()
+	entries := make([]*supplyAuditLogEntry, 0, len(auditLog))
+
+	for _, change := range auditLog {
+		if from != nil && change.BlockNumber < uint64(*from) {
+			continue
+		}
+
+		if to != nil && change.BlockNumber > uint64(*to) {
+			continue
+		}
+
+		entries = append(entries, &supplyAuditLogEntry{
+			BlockNumber: argUint64(change.BlockNumber),
+			Amount:      *argBigPtr(change.Amount),
+			Type:        string(change.Type),
+			Timestamp:   argUint64(change.Timestamp),
+			Caller:      change.Caller,
+		})
+	}
+
+	return entries, nil
+}
+
+// ProjectedSupplyAt returns the deterministic supply at the given block, which may
+// be in the future, and the block where the cap is reached if it is already past
+func (s *Supply) ProjectedSupplyAt(blockNumber argUint64) (interface{}, error) {
+	projection := s.store.ProjectSupplyAt(uint64(blockNumber))
+
+	res := &projectedSupply{
+		BlockNumber: argUint64(projection.Block),
+		Supply:      *argBigPtr(projection.Supply),
+		SupplyAZE:   projection.SupplyAZE,
+		PastCap:     projection.PastCap,
+	}
+
+	if projection.PastCap {
+		res.CapBlock = argUintPtr(projection.CapBlock)
+	}
+
+	return res, nil
+}
+
+// GetActiveEmissionRate returns the reward minted at the given block in wei, which
+// is zero once the cap is reached
+func (s *Supply) GetActiveEmissionRate(blockNumber argUint64) (interface{}, error) {
+	return argBigPtr(s.store.CurrentEmissionRate(uint64(blockNumber))), nil
+}
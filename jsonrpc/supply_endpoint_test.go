@@ -0,0 +1,128 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/staking"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSupplyStore struct {
+	supply     *big.Int
+	maxSupply  *big.Int
+	auditLog   []staking.SupplyAuditLog
+	projection staking.ProjectedSupply
+	rates      map[uint64]*big.Int
+}
+
+func (m *mockSupplyStore) GetCurrentSupply() *big.Int {
+	return m.supply
+}
+
+func (m *mockSupplyStore) GetMaxSupply() *big.Int {
+	return m.maxSupply
+}
+
+func (m *mockSupplyStore) GetSupplyAuditLog() []staking.SupplyAuditLog {
+	return m.auditLog
+}
+
+func (m *mockSupplyStore) ProjectSupplyAt(blockNumber uint64) staking.ProjectedSupply {
+	projection := m.projection
+	projection.Block = blockNumber
+
+	return projection
+}
+
+func (m *mockSupplyStore) CurrentEmissionRate(blockNumber uint64) *big.Int {
+	if rate, ok := m.rates[blockNumber]; ok {
+		return rate
+	}
+
+	return big.NewInt(0)
+}
+
+func TestSupplyEndpoint(t *testing.T) {
+	store := &mockSupplyStore{
+		supply:    big.NewInt(3000),
+		maxSupply: big.NewInt(10000),
+		auditLog: []staking.SupplyAuditLog{
+			{BlockNumber: 1, Amount: big.NewInt(1000), Type: staking.ChangeMint, Caller: "consensus_engine"},
+			{BlockNumber: 2, Amount: big.NewInt(255), Type: staking.ChangeMint, Caller: "consensus_engine"},
+			{BlockNumber: 3, Amount: big.NewInt(16), Type: staking.ChangeBurn, Caller: "consensus_engine"},
+		},
+	}
+	supply := &Supply{store}
+
+	total, err := supply.GetTotalSupply()
+	require.NoError(t, err)
+	assert.Equal(t, argBigPtr(big.NewInt(3000)), total)
+
+	maxSupply, err := supply.GetMaxSupply()
+	require.NoError(t, err)
+	assert.Equal(t, argBigPtr(big.NewInt(10000)), maxSupply)
+
+	res, err := supply.GetAuditLog(nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, res, 3)
+
+	res, err = supply.GetAuditLog(argUintPtr(2), argUintPtr(3))
+	require.NoError(t, err)
+
+	entries, ok := res.([]*supplyAuditLogEntry)
+	require.True(t, ok)
+	require.Len(t, entries, 2)
+
+	amount, err := entries[0].Amount.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "0xff", string(amount))
+	assert.Equal(t, "burn", entries[1].Type)
+}
+
+func TestSupplyEndpoint_ProjectedSupplyAt(t *testing.T) {
+	store := &mockSupplyStore{
+		projection: staking.ProjectedSupply{
+			Supply:    big.NewInt(5000),
+			SupplyAZE: "0.000000000000005",
+		},
+	}
+	supply := &Supply{store}
+
+	res, err := supply.ProjectedSupplyAt(argUint64(10))
+	require.NoError(t, err)
+
+	projection, ok := res.(*projectedSupply)
+	require.True(t, ok)
+	assert.Equal(t, argUint64(10), projection.BlockNumber)
+	assert.Equal(t, *argBigPtr(big.NewInt(5000)), projection.Supply)
+	assert.False(t, projection.PastCap)
+	assert.Nil(t, projection.CapBlock)
+
+	store.projection.PastCap = true
+	store.projection.CapBlock = 7
+
+	res, err = supply.ProjectedSupplyAt(argUint64(10))
+	require.NoError(t, err)
+
+	projection, ok = res.(*projectedSupply)
+	require.True(t, ok)
+	assert.True(t, projection.PastCap)
+	assert.Equal(t, argUintPtr(7), projection.CapBlock)
+}
+
+func TestSupplyEndpoint_GetActiveEmissionRate(t *testing.T) {
+	store := &mockSupplyStore{
+		rates: map[uint64]*big.Int{5: big.NewInt(1000)},
+	}
+	supply := &Supply{store}
+
+	rate, err := supply.GetActiveEmissionRate(argUint64(5))
+	require.NoError(t, err)
+	assert.Equal(t, argBigPtr(big.NewInt(1000)), rate)
+
+	rate, err = supply.GetActiveEmissionRate(argUint64(6))
+	require.NoError(t, err)
+	assert.Equal(t, argBigPtr(big.NewInt(0)), rate)
+}
@@ -0,0 +1,614 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: server/proto/supply.proto
+
+package proto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on TotalSupplyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no
+// violations.
+func (m *TotalSupplyResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TotalSupplyResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// TotalSupplyResponseMultiError, or nil if none found.
+func (m *TotalSupplyResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TotalSupplyResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for CurrentSupply
+
+	// no validation rules for MaxSupply
+
+	if len(errors) > 0 {
+		return TotalSupplyResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// TotalSupplyResponseMultiError is an error wrapping multiple validation
+// errors returned by TotalSupplyResponse.ValidateAll() if the designated
+// constraints aren't met.
+type TotalSupplyResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TotalSupplyResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TotalSupplyResponseMultiError) AllErrors() []error { return m }
+
+// TotalSupplyResponseValidationError is the validation error returned by
+// TotalSupplyResponse.Validate if the designated constraints aren't met.
+type TotalSupplyResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TotalSupplyResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TotalSupplyResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TotalSupplyResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TotalSupplyResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TotalSupplyResponseValidationError) ErrorName() string {
+	return "TotalSupplyResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e TotalSupplyResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTotalSupplyResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TotalSupplyResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TotalSupplyResponseValidationError{}
+
+// Validate checks the field values on AuditLogRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *AuditLogRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AuditLogRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AuditLogRequestMultiError, or nil if none found.
+func (m *AuditLogRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AuditLogRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for FromBlock
+
+	// no validation rules for ToBlock
+
+	if len(errors) > 0 {
+		return AuditLogRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// AuditLogRequestMultiError is an error wrapping multiple validation errors
+// returned by AuditLogRequest.ValidateAll() if the designated constraints
+// aren't met.
+type AuditLogRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AuditLogRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AuditLogRequestMultiError) AllErrors() []error { return m }
+
+// AuditLogRequestValidationError is the validation error returned by
+// AuditLogRequest.Validate if the designated constraints aren't met.
+type AuditLogRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AuditLogRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AuditLogRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AuditLogRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AuditLogRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AuditLogRequestValidationError) ErrorName() string { return "AuditLogRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AuditLogRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAuditLogRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AuditLogRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AuditLogRequestValidationError{}
+
+// Validate checks the field values on AuditLogResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *AuditLogResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AuditLogResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AuditLogResponseMultiError, or nil if none found.
+func (m *AuditLogResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AuditLogResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetEntries() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, AuditLogResponseValidationError{
+						field:  fmt.Sprintf("Entries[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, AuditLogResponseValidationError{
+						field:  fmt.Sprintf("Entries[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return AuditLogResponseValidationError{
+					field:  fmt.Sprintf("Entries[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return AuditLogResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// AuditLogResponseMultiError is an error wrapping multiple validation errors
+// returned by AuditLogResponse.ValidateAll() if the designated constraints
+// aren't met.
+type AuditLogResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AuditLogResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AuditLogResponseMultiError) AllErrors() []error { return m }
+
+// AuditLogResponseValidationError is the validation error returned by
+// AuditLogResponse.Validate if the designated constraints aren't met.
+type AuditLogResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AuditLogResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AuditLogResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AuditLogResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AuditLogResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AuditLogResponseValidationError) ErrorName() string { return "AuditLogResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AuditLogResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAuditLogResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AuditLogResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AuditLogResponseValidationError{}
+
+// Validate checks the field values on AuditLogEntry with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AuditLogEntry) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AuditLogEntry with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AuditLogEntryMultiError, or
+// nil if none found.
+func (m *AuditLogEntry) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AuditLogEntry) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for BlockNumber
+
+	// no validation rules for Amount
+
+	// no validation rules for Type
+
+	// no validation rules for Timestamp
+
+	// no validation rules for Caller
+
+	// no validation rules for Reason
+
+	// no validation rules for Recipient
+
+	if len(errors) > 0 {
+		return AuditLogEntryMultiError(errors)
+	}
+
+	return nil
+}
+
+// AuditLogEntryMultiError is an error wrapping multiple validation errors
+// returned by AuditLogEntry.ValidateAll() if the designated constraints
+// aren't met.
+type AuditLogEntryMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AuditLogEntryMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AuditLogEntryMultiError) AllErrors() []error { return m }
+
+// AuditLogEntryValidationError is the validation error returned by
+// AuditLogEntry.Validate if the designated constraints aren't met.
+type AuditLogEntryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AuditLogEntryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AuditLogEntryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AuditLogEntryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AuditLogEntryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AuditLogEntryValidationError) ErrorName() string { return "AuditLogEntryValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AuditLogEntryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAuditLogEntry.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AuditLogEntryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AuditLogEntryValidationError{}
+
+// Validate checks the field values on SupplyStatsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no
+// violations.
+func (m *SupplyStatsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SupplyStatsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SupplyStatsResponseMultiError, or nil if none found.
+func (m *SupplyStatsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SupplyStatsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for CurrentSupply
+
+	// no validation rules for MaxSupply
+
+	// no validation rules for RemainingMintable
+
+	// no validation rules for TotalMinted
+
+	// no validation rules for TotalBurned
+
+	// no validation rules for AuditEntryCount
+
+	// no validation rules for LastBlock
+
+	// no validation rules for HasLastBlock
+
+	if len(errors) > 0 {
+		return SupplyStatsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// SupplyStatsResponseMultiError is an error wrapping multiple validation
+// errors returned by SupplyStatsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type SupplyStatsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SupplyStatsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SupplyStatsResponseMultiError) AllErrors() []error { return m }
+
+// SupplyStatsResponseValidationError is the validation error returned by
+// SupplyStatsResponse.Validate if the designated constraints aren't met.
+type SupplyStatsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SupplyStatsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SupplyStatsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SupplyStatsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SupplyStatsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SupplyStatsResponseValidationError) ErrorName() string {
+	return "SupplyStatsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SupplyStatsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSupplyStatsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SupplyStatsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SupplyStatsResponseValidationError{}
@@ -0,0 +1,184 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.7
+// source: server/proto/supply.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SupplyServiceClient is the client API for SupplyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SupplyServiceClient interface {
+	// GetTotalSupply returns the current and maximum token supply
+	GetTotalSupply(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TotalSupplyResponse, error)
+	// GetAuditLog returns the supply audit log entries within a block range
+	GetAuditLog(ctx context.Context, in *AuditLogRequest, opts ...grpc.CallOption) (*AuditLogResponse, error)
+	// GetStats returns the aggregated supply statistics
+	GetStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SupplyStatsResponse, error)
+}
+
+type supplyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSupplyServiceClient(cc grpc.ClientConnInterface) SupplyServiceClient {
+	return &supplyServiceClient{cc}
+}
+
+func (c *supplyServiceClient) GetTotalSupply(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TotalSupplyResponse, error) {
+	out := new(TotalSupplyResponse)
+	err := c.cc.Invoke(ctx, "/v1.SupplyService/GetTotalSupply", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplyServiceClient) GetAuditLog(ctx context.Context, in *AuditLogRequest, opts ...grpc.CallOption) (*AuditLogResponse, error) {
+	out := new(AuditLogResponse)
+	err := c.cc.Invoke(ctx, "/v1.SupplyService/GetAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supplyServiceClient) GetStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SupplyStatsResponse, error) {
+	out := new(SupplyStatsResponse)
+	err := c.cc.Invoke(ctx, "/v1.SupplyService/GetStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SupplyServiceServer is the server API for SupplyService service.
+// All implementations must embed UnimplementedSupplyServiceServer
+// for forward compatibility
+type SupplyServiceServer interface {
+	// GetTotalSupply returns the current and maximum token supply
+	GetTotalSupply(context.Context, *emptypb.Empty) (*TotalSupplyResponse, error)
+	// GetAuditLog returns the supply audit log entries within a block range
+	GetAuditLog(context.Context, *AuditLogRequest) (*AuditLogResponse, error)
+	// GetStats returns the aggregated supply statistics
+	GetStats(context.Context, *emptypb.Empty) (*SupplyStatsResponse, error)
+	mustEmbedUnimplementedSupplyServiceServer()
+}
+
+// UnimplementedSupplyServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSupplyServiceServer struct {
+}
+
+func (UnimplementedSupplyServiceServer) GetTotalSupply(context.Context, *emptypb.Empty) (*TotalSupplyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTotalSupply not implemented")
+}
+func (UnimplementedSupplyServiceServer) GetAuditLog(context.Context, *AuditLogRequest) (*AuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuditLog not implemented")
+}
+func (UnimplementedSupplyServiceServer) GetStats(context.Context, *emptypb.Empty) (*SupplyStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedSupplyServiceServer) mustEmbedUnimplementedSupplyServiceServer() {}
+
+// UnsafeSupplyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SupplyServiceServer will
+// result in compilation errors.
+type UnsafeSupplyServiceServer interface {
+	mustEmbedUnimplementedSupplyServiceServer()
+}
+
+func RegisterSupplyServiceServer(s grpc.ServiceRegistrar, srv SupplyServiceServer) {
+	s.RegisterService(&SupplyService_ServiceDesc, srv)
+}
+
+func _SupplyService_GetTotalSupply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplyServiceServer).GetTotalSupply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SupplyService/GetTotalSupply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplyServiceServer).GetTotalSupply(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplyService_GetAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplyServiceServer).GetAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SupplyService/GetAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplyServiceServer).GetAuditLog(ctx, req.(*AuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupplyService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupplyServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SupplyService/GetStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupplyServiceServer).GetStats(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SupplyService_ServiceDesc is the grpc.ServiceDesc for SupplyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SupplyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.SupplyService",
+	HandlerType: (*SupplyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTotalSupply",
+			Handler:    _SupplyService_GetTotalSupply_Handler,
+		},
+		{
+			MethodName: "GetAuditLog",
+			Handler:    _SupplyService_GetAuditLog_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _SupplyService_GetStats_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "server/proto/supply.proto",
+}
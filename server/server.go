@@ -282,6 +282,15 @@ func NewServer(config *Config) (*Server, error) {
 
 	// After loading config.Chain.Genesis.Alloc and before starting consensus, set the cache:
 	stakingHelper.SetGenesisAllocCache(config.Chain.Genesis.Alloc)
+	stakingHelper.SetSupplyLogger(logger.Named("supply"))
+
+	if config.DataDir != "" {
+		if err := stakingHelper.SetCapReachedFlagPath(
+			filepath.Join(config.DataDir, "supply_cap_reached"),
+		); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := initForkManager(engineName, config.Chain); err != nil {
 		return nil, err
@@ -892,6 +901,7 @@ func (s *Server) setupJSONRPC() error {
 // setupGRPC sets up the grpc server and listens on tcp
 func (s *Server) setupGRPC() error {
 	proto.RegisterSystemServer(s.grpcServer, &systemService{server: s})
+	proto.RegisterSupplyServiceServer(s.grpcServer, newSupplyService())
 
 	lis, err := net.Listen("tcp", s.config.GRPCAddr.String())
 	if err != nil {
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+type supplyService struct {
+	proto.UnimplementedSupplyServiceServer
+
+	// reader resolves the tracker on every call, since the global tracker
+	// is replaced when it is re-initialized from genesis
+	reader func() stakingHelper.SupplyReader
+}
+
+func newSupplyService() *supplyService {
+	return &supplyService{
+		reader: func() stakingHelper.SupplyReader {
+			return stakingHelper.GetGlobalSupplyTracker()
+		},
+	}
+}
+
+// GetTotalSupply returns the current and the maximum supply, in wei
+func (s *supplyService) GetTotalSupply(context.Context, *empty.Empty) (*proto.TotalSupplyResponse, error) {
+	return &proto.TotalSupplyResponse{
+		CurrentSupply: s.reader().GetCurrentSupply().String(),
+		MaxSupply:     stakingHelper.GetMaxSupply().String(),
+	}, nil
+}
+
+// GetAuditLog returns the audit entries recorded between fromBlock and toBlock,
+// both inclusive. A zero toBlock returns every entry from fromBlock onwards
+func (s *supplyService) GetAuditLog(
+	_ context.Context,
+	req *proto.AuditLogRequest,
+) (*proto.AuditLogResponse, error) {
+	if req.ToBlock != 0 && req.ToBlock < req.FromBlock {
+		return nil, errors.New("toBlock must not be lower than fromBlock")
+	}
+
+	resp := &proto.AuditLogResponse{}
+
+	for _, entry := range s.reader().GetAuditLog() {
+		if entry.BlockNumber < req.FromBlock || (req.ToBlock != 0 && entry.BlockNumber > req.ToBlock) {
+			continue
+		}
+
+		resp.Entries = append(resp.Entries, toProtoAuditEntry(entry))
+	}
+
+	return resp, nil
+}
+
+// GetStats returns the aggregated supply statistics
+func (s *supplyService) GetStats(context.Context, *empty.Empty) (*proto.SupplyStatsResponse, error) {
+	stats := s.reader().Stats()
+
+	return &proto.SupplyStatsResponse{
+		CurrentSupply:     stats.CurrentSupply.String(),
+		MaxSupply:         stats.MaxSupply.String(),
+		RemainingMintable: stats.RemainingMintable.String(),
+		TotalMinted:       stats.TotalMinted.String(),
+		TotalBurned:       stats.TotalBurned.String(),
+		AuditEntryCount:   uint64(stats.AuditEntryCount),
+		LastBlock:         stats.LastBlock,
+		HasLastBlock:      stats.HasLastBlock,
+	}, nil
+}
+
+func toProtoAuditEntry(entry stakingHelper.SupplyAuditLog) *proto.AuditLogEntry {
+	res := &proto.AuditLogEntry{
+		BlockNumber: entry.BlockNumber,
+		Amount:      "0",
+		Type:        string(entry.Type),
+		Timestamp:   entry.Timestamp,
+		Caller:      entry.Caller,
+		Reason:      entry.Reason,
+	}
+
+	if entry.Amount != nil {
+		res.Amount = entry.Amount.String()
+	}
+
+	if entry.Recipient != nil {
+		res.Recipient = entry.Recipient.String()
+	}
+
+	return res
+}
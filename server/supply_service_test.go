@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSupplyReader struct {
+	stakingHelper.SupplyReader
+
+	entries []stakingHelper.SupplyAuditLog
+}
+
+func (m *mockSupplyReader) GetAuditLog() []stakingHelper.SupplyAuditLog {
+	return m.entries
+}
+
+func TestSupplyService_GetAuditLog(t *testing.T) {
+	t.Parallel()
+
+	recipient := types.StringToAddress("1")
+	reader := &mockSupplyReader{
+		entries: []stakingHelper.SupplyAuditLog{
+			{BlockNumber: 1, Amount: big.NewInt(10), Type: stakingHelper.ChangeMint, Recipient: &recipient},
+			{BlockNumber: 5, Amount: big.NewInt(20), Type: stakingHelper.ChangeBurn},
+			{BlockNumber: 9, Amount: big.NewInt(30), Type: stakingHelper.ChangeMint},
+		},
+	}
+
+	service := &supplyService{
+		reader: func() stakingHelper.SupplyReader { return reader },
+	}
+
+	blocks := func(resp *proto.AuditLogResponse) []uint64 {
+		res := make([]uint64, 0, len(resp.Entries))
+		for _, entry := range resp.Entries {
+			res = append(res, entry.BlockNumber)
+		}
+
+		return res
+	}
+
+	resp, err := service.GetAuditLog(context.Background(), &proto.AuditLogRequest{FromBlock: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{5, 9}, blocks(resp))
+
+	resp, err = service.GetAuditLog(context.Background(), &proto.AuditLogRequest{FromBlock: 1, ToBlock: 5})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 5}, blocks(resp))
+	assert.Equal(t, "10", resp.Entries[0].Amount)
+	assert.Equal(t, "mint", resp.Entries[0].Type)
+	assert.Equal(t, recipient.String(), resp.Entries[0].Recipient)
+
+	_, err = service.GetAuditLog(context.Background(), &proto.AuditLogRequest{FromBlock: 6, ToBlock: 5})
+	assert.Error(t, err)
+}
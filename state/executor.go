@@ -204,15 +204,16 @@ func (e *Executor) BeginTxn(
 	}
 
 	txn := &Transition{
-		logger:   e.logger,
-		ctx:      txCtx,
-		state:    newTxn,
-		snap:     auxSnap2,
-		getHash:  e.GetHash(header),
-		auxState: e.state,
-		config:   forkConfig,
-		gasPool:  uint64(txCtx.GasLimit),
-		executor: e,
+		logger:    e.logger,
+		ctx:       txCtx,
+		state:     newTxn,
+		snap:      auxSnap2,
+		getHash:   e.GetHash(header),
+		auxState:  e.state,
+		blockHash: header.Hash,
+		config:    forkConfig,
+		gasPool:   uint64(txCtx.GasLimit),
+		executor:  e,
 
 		receipts: []*types.Receipt{},
 		totalGas: 0,
@@ -266,6 +267,9 @@ type Transition struct {
 	gasPool  uint64
 	executor *Executor
 
+	// hash of the block being executed, the fee effects of its transactions are staged under it
+	blockHash types.Hash
+
 	// result
 	receipts []*types.Receipt
 	totalGas uint64
@@ -666,7 +670,15 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 
 	if stakingHelper.CheckStakingContractDeployed(t) {
 		// Distribute transaction fees: 50% to owner, 50% to block producer
-		if err := stakingHelper.DistributeTxFeesToValidator(t.state, coinbaseFee, ownerAddress, blockProducerAddress); err != nil {
+		if err := stakingHelper.DistributeTxFeesToValidator(
+			t.state,
+			coinbaseFee,
+			ownerAddress,
+			blockProducerAddress,
+			uint64(t.ctx.Number),
+			t.blockHash,
+			msg.Hash,
+		); err != nil {
 			// Fallback to original coinbase payment if distribution fails
 			t.state.AddBalance(t.ctx.Coinbase, coinbaseFee)
 		}